@@ -53,7 +53,7 @@ func (g Graph) ClearMarks() {
 // start, adding the nodes in add. This is a destructive operation; at the end,
 // the graph will have all nodes in the return set set to MarkTrue and the rest
 // set to MarkNone.
-func (g Graph) Explore(start map[*Node]bool, hard bool,
+func (g Graph) Explore(start map[*Node]bool, tricks Tricks,
 	add ...*Node) map[*Node]bool {
 	// copy set, and mark nodes accordingly
 	g.ClearMarks()
@@ -87,7 +87,7 @@ func (g Graph) Explore(start map[*Node]bool, hard bool,
 		for node := range frontier {
 			// if we can reach the node, add it to the reached set and add its
 			// (previously unchecked) children to the frontier
-			if node.GetMark(node, hard) == MarkTrue {
+			if node.GetMark(node, tricks) == MarkTrue {
 				reached[node] = true
 				node.Mark = MarkTrue
 				for _, child := range node.children {
@@ -108,8 +108,8 @@ func (g Graph) Explore(start map[*Node]bool, hard bool,
 
 // ExploreFromStart calls explore without specific start and add nodes, instead
 // exploring the entirety of the existing graph.
-func (g Graph) ExploreFromStart(hard bool) map[*Node]bool {
-	return g.Explore(nil, hard, g["start"])
+func (g Graph) ExploreFromStart(tricks Tricks) map[*Node]bool {
+	return g.Explore(nil, tricks, g["start"])
 }
 
 // Reduce returns a version of the graph that is 1. only relevant to the given
@@ -185,7 +185,7 @@ func copyGraph(old Graph) Graph {
 	// add nodes
 	for name, node := range old {
 		new[name] = NewNode(node.Name, node.Type, node.IsStep, node.IsSlot,
-			node.IsHard)
+			node.Trick)
 	}
 
 	// add relationships
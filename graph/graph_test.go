@@ -3,7 +3,7 @@ package graph
 import "testing"
 
 func newNormalNode(name string, nodeType NodeType) *Node {
-	return NewNode(name, nodeType, false, false, false)
+	return NewNode(name, nodeType, false, false, "")
 }
 
 // tests Graph.Reduce on a graph that is effectively a linked list
@@ -35,29 +35,33 @@ const (
 	OrType
 )
 
-// A Node is a single point in the directed graph.
+// Tricks is a set of enabled trick names. A nil set enables no tricks.
+type Tricks map[string]bool
+
+// A Node is a single point in the directed graph. If the node's Trick is
+// non-empty, the node is only satisfied when that trick is enabled.
 type Node struct {
 	Name     string
 	Type     NodeType
-	GetMark  func(*Node, bool) Mark
+	GetMark  func(*Node, Tricks) Mark
 	IsStep   bool
 	IsSlot   bool
-	IsHard   bool
+	Trick    string
 	Mark     Mark
 	parents  []*Node
 	children []*Node
 }
 
 // NewNode returns a new unconnected graph node, not yet part of any graph.
-func NewNode(name string, nodeType NodeType,
-	isStep, isSlot, isHard bool) *Node {
+func NewNode(name string, nodeType NodeType, isStep, isSlot bool,
+	trick string) *Node {
 	// create node
 	n := Node{
 		Name:     name,
 		Type:     nodeType,
 		IsStep:   isStep,
 		IsSlot:   isSlot,
-		IsHard:   isHard,
+		Trick:    trick,
 		Mark:     MarkNone,
 		parents:  make([]*Node, 0),
 		children: make([]*Node, 0),
@@ -78,16 +82,20 @@ func NewNode(name string, nodeType NodeType,
 	return &n
 }
 
-func getAndMark(n *Node, hard bool) Mark {
+// allows returns true iff the node's trick (if any) is in the set.
+func (tricks Tricks) allows(n *Node) bool {
+	return n.Trick == "" || tricks[n.Trick]
+}
+
+func getAndMark(n *Node, tricks Tricks) Mark {
+	if !tricks.allows(n) {
+		return MarkFalse
+	}
+
 	if n.Mark == MarkNone {
 		n.Mark = MarkPending
 		for _, parent := range n.parents {
-			if !hard && parent.IsHard {
-				n.Mark = MarkFalse
-				return n.Mark
-			}
-
-			switch parent.GetMark(parent, hard) {
+			switch parent.GetMark(parent, tricks) {
 			case MarkPending, MarkFalse:
 				n.Mark = MarkNone
 				return MarkFalse
@@ -101,7 +109,11 @@ func getAndMark(n *Node, hard bool) Mark {
 	return n.Mark
 }
 
-func getOrMark(n *Node, hard bool) Mark {
+func getOrMark(n *Node, tricks Tricks) Mark {
+	if !tricks.allows(n) {
+		return MarkFalse
+	}
+
 	if n.Mark == MarkNone {
 		n.Mark = MarkPending
 		allPending := true
@@ -109,7 +121,7 @@ func getOrMark(n *Node, hard bool) Mark {
 		// prioritize already satisfied nodes
 	OrPeekLoop:
 		for _, parent := range n.parents {
-			if !hard && parent.IsHard {
+			if !tricks.allows(parent) {
 				continue
 			}
 
@@ -127,11 +139,7 @@ func getOrMark(n *Node, hard bool) Mark {
 		if n.Mark == MarkPending {
 		OrGetLoop:
 			for _, parent := range n.parents {
-				if !hard && parent.IsHard {
-					continue
-				}
-
-				switch parent.GetMark(parent, hard) {
+				switch parent.GetMark(parent, tricks) {
 				case MarkTrue:
 					n.Mark = MarkTrue
 					allPending = false
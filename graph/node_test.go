@@ -81,11 +81,11 @@ func TestNodeGetMark(t *testing.T) {
 	and1, or1 := makeAndNode(), makeOrNode()
 
 	// orphan AndNodes are true
-	if mark := and1.GetMark(and1, false); mark != MarkTrue {
+	if mark := and1.GetMark(and1, nil); mark != MarkTrue {
 		t.Fatalf("want %d, got %d", MarkTrue, mark)
 	}
 	// orphan OrNodes are false
-	if mark := or1.GetMark(or1, false); mark != MarkFalse {
+	if mark := or1.GetMark(or1, nil); mark != MarkFalse {
 		t.Fatalf("want %d, got %d", MarkFalse, mark)
 	}
 
@@ -94,7 +94,7 @@ func TestNodeGetMark(t *testing.T) {
 	clearMarks(and1, or1)
 
 	// AndNodes need all parents to succeed
-	if mark := and1.GetMark(and1, false); mark != MarkFalse {
+	if mark := and1.GetMark(and1, nil); mark != MarkFalse {
 		t.Fatalf("want %d, got %d", MarkFalse, mark)
 	}
 
@@ -103,12 +103,12 @@ func TestNodeGetMark(t *testing.T) {
 	clearMarks(and1, or1, and2)
 
 	// OrNodes need one
-	if mark := or1.GetMark(or1, false); mark != MarkFalse {
+	if mark := or1.GetMark(or1, nil); mark != MarkFalse {
 		t.Fatalf("want %d, got %d", MarkFalse, mark)
 	}
 	// make sure the OrNode gets the same results by peeking
 	or1.Mark = MarkNone
-	if mark := or1.GetMark(or1, false); mark != MarkFalse {
+	if mark := or1.GetMark(or1, nil); mark != MarkFalse {
 		t.Fatalf("want %d, got %d", MarkFalse, mark)
 	}
 
@@ -119,16 +119,16 @@ func TestNodeGetMark(t *testing.T) {
 	clearMarks(and1, or1, and2, or2)
 
 	// and only one
-	if mark := or1.GetMark(or1, false); mark != MarkTrue {
+	if mark := or1.GetMark(or1, nil); mark != MarkTrue {
 		t.Fatalf("want %d, got %d", MarkTrue, mark)
 	}
 	// make sure the OrNode gets the same results by peeking
 	or1.Mark = MarkNone
-	if mark := or1.GetMark(or1, false); mark != MarkTrue {
+	if mark := or1.GetMark(or1, nil); mark != MarkTrue {
 		t.Fatalf("want %d, got %d", MarkTrue, mark)
 	}
 	// and now the AndNode should be satisfied
-	if mark := and1.GetMark(and1, false); mark != MarkTrue {
+	if mark := and1.GetMark(and1, nil); mark != MarkTrue {
 		t.Fatalf("want %d, got %d", MarkTrue, mark)
 	}
 
@@ -142,43 +142,49 @@ func TestNodeGetMark(t *testing.T) {
 	or1.AddParents(or2)
 	or2.AddParents(or1)
 	clearMarks(and1, and2, or1, or2)
-	if mark := and1.GetMark(and1, false); mark != MarkFalse {
+	if mark := and1.GetMark(and1, nil); mark != MarkFalse {
 		t.Fatalf("want %d, got %d", MarkFalse, mark)
 	}
-	if mark := or1.GetMark(or1, false); mark != MarkFalse {
+	if mark := or1.GetMark(or1, nil); mark != MarkFalse {
 		t.Fatalf("want %d, got %d", MarkFalse, mark)
 	}
 }
 
-func TestHardNodes(t *testing.T) {
-	hard1 := NewNode("hard1", AndType, false, false, true)
-	if mark := hard1.GetMark(hard1, false); mark != MarkFalse {
+func TestTrickNodes(t *testing.T) {
+	tricks := Tricks{"trick": true}
+
+	hard1 := NewNode("hard1", AndType, false, false, "trick")
+	if mark := hard1.GetMark(hard1, nil); mark != MarkFalse {
+		t.Fatalf("want %d, got %d", MarkFalse, mark)
+	}
+	clearMarks(hard1)
+	if mark := hard1.GetMark(hard1, Tricks{"other": true}); mark != MarkFalse {
 		t.Fatalf("want %d, got %d", MarkFalse, mark)
 	}
 	clearMarks(hard1)
-	if mark := hard1.GetMark(hard1, true); mark != MarkTrue {
+	if mark := hard1.GetMark(hard1, tricks); mark != MarkTrue {
 		t.Fatalf("want %d, got %d", MarkTrue, mark)
 	}
 	clearMarks(hard1)
 
-	and1 := NewNode("and1", AndType, false, false, false)
+	and1 := NewNode("and1", AndType, false, false, "")
 	and1.AddParents(hard1)
-	if mark := and1.GetMark(and1, false); mark != MarkFalse {
+	if mark := and1.GetMark(and1, nil); mark != MarkFalse {
 		t.Fatalf("want %d, got %d", MarkFalse, mark)
 	}
 	clearMarks(and1, hard1)
-	if mark := and1.GetMark(and1, true); mark != MarkTrue {
+	if mark := and1.GetMark(and1, tricks); mark != MarkTrue {
 		t.Fatalf("want %d, got %d", MarkTrue, mark)
 	}
 	clearMarks(and1, hard1)
 
-	or1 := NewNode("or1", OrType, false, false, false)
+	or1 := NewNode("or1", OrType, false, false, "")
 	or1.AddParents(hard1)
-	if mark := or1.GetMark(or1, false); mark != MarkFalse {
+	if mark := or1.GetMark(or1, nil); mark != MarkFalse {
 		t.Fatalf("want %d, got %d", MarkFalse, mark)
 	}
 	clearMarks(or1, hard1)
-	if mark := or1.GetMark(or1, true); mark != MarkTrue {
+	if mark := or1.GetMark(or1, tricks); mark != MarkTrue {
 		t.Fatalf("want %d, got %d", MarkTrue, mark)
 	}
 	clearMarks(or1, hard1)
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/jangler/oracles-randomizer/graph"
+	"github.com/jangler/oracles-randomizer/logic"
+	"github.com/jangler/oracles-randomizer/rom"
+)
+
+// the number of hints of each type to generate.
+const (
+	wayOfTheHeroHints = 4
+	barrenHints       = 3
+	itemHints         = 5
+)
+
+// generateHints returns hint text for a route: regions that hold items
+// required to beat the game ("way of the hero"), regions that hold none
+// ("barren"), and the regions that specific required items are in.
+// hints are chosen using the route's seed, so they're the same every time.
+func generateHints(ri *RouteInfo, game int, tricks graph.Tricks) []string {
+	src := rand.New(rand.NewSource(int64(ri.Seed)))
+	checks := getChecks(ri)
+	regions := getSlotRegions(game, checks)
+
+	// sort slots so that hints don't depend on map order
+	slots := make([]*graph.Node, 0, len(checks))
+	for slot := range checks {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].Name < slots[j].Name
+	})
+
+	required := getRequiredSlots(ri.Route, checks, slots, tricks)
+
+	// sort regions into ones with and without required items
+	heroRegions, barrenRegions := make([]string, 0), make([]string, 0)
+	for _, slot := range slots {
+		region := regions[slot.Name]
+		if required[slot] && !stringInSlice(region, heroRegions) {
+			heroRegions = append(heroRegions, region)
+		}
+	}
+	for _, slot := range slots {
+		region := regions[slot.Name]
+		if !stringInSlice(region, heroRegions) &&
+			!stringInSlice(region, barrenRegions) {
+			barrenRegions = append(barrenRegions, region)
+		}
+	}
+
+	hints := make([]string, 0, wayOfTheHeroHints+barrenHints+itemHints)
+	heroRegions = pickStrings(src, heroRegions, wayOfTheHeroHints)
+	for _, region := range heroRegions {
+		hints = append(hints, fmt.Sprintf(
+			"They say that %s is on the way of the hero.", titleCase(region)))
+	}
+	for _, region := range pickStrings(src, barrenRegions, barrenHints) {
+		hints = append(hints, fmt.Sprintf(
+			"They say that %s holds nothing you need.", titleCase(region)))
+	}
+
+	// item hints are for required items that aren't in hinted regions. seed
+	// trees and dungeon items in their own dungeons would be pointless to
+	// hint.
+	itemSlots := make([]string, 0)
+	for _, slot := range slots {
+		if required[slot] && !stringInSlice(regions[slot.Name], heroRegions) &&
+			!slotIsSeedTree(slot.Name) &&
+			(dungeonIndex(checks[slot]) == -1 ||
+				dungeonIndex(checks[slot]) != dungeonIndex(slot)) {
+			itemSlots = append(itemSlots, slot.Name)
+		}
+	}
+	for _, slotName := range pickStrings(src, itemSlots, itemHints) {
+		item := checks[ri.Route.Graph[slotName]]
+		hints = append(hints, fmt.Sprintf(
+			"They say that the %s can be found in %s.",
+			getNiceName(item.Name), titleCase(regions[slotName])))
+	}
+
+	return hints
+}
+
+// getSlotRegions returns a map of slot names to hint region names.
+func getSlotRegions(game int,
+	checks map[*graph.Node]*graph.Node) map[string]string {
+	slotNames := make([]string, 0, len(checks))
+	for slot := range checks {
+		slotNames = append(slotNames, slot.Name)
+	}
+
+	if game == rom.GameSeasons {
+		return logic.SeasonsRegions(slotNames)
+	}
+	return logic.AgesRegions(slotNames)
+}
+
+// getRequiredSlots returns the set of slots whose items are needed to beat the
+// game, determined by whether the game can still be beaten without each item.
+func getRequiredSlots(r *Route, checks map[*graph.Node]*graph.Node,
+	slots []*graph.Node, tricks graph.Tricks) map[*graph.Node]bool {
+	required := make(map[*graph.Node]bool)
+	done := r.Graph["done"]
+
+	for _, slot := range slots {
+		item := checks[slot]
+		if itemIsJunk(r.ROM, item.Name) {
+			continue
+		}
+
+		// remove the item from the checks and see if the game is beatable
+		others := make(map[*graph.Node]*graph.Node, len(checks)-1)
+		for k, v := range checks {
+			if k != slot {
+				others[k] = v
+			}
+		}
+		item.RemoveParent(slot)
+		spheres := getSpheres(r.Graph, others, tricks)
+		item.AddParents(slot)
+
+		required[slot] = !nodeInSpheres(done, spheres)
+	}
+
+	r.Graph.ClearMarks()
+	return required
+}
+
+// returns true iff the node is in any of the spheres.
+func nodeInSpheres(node *graph.Node, spheres [][]*graph.Node) bool {
+	for _, sphere := range spheres {
+		if graph.IsNodeInSlice(node, sphere) {
+			return true
+		}
+	}
+	return false
+}
+
+// pickStrings returns up to n random strings from the slice, without
+// repeats.
+func pickStrings(src *rand.Rand, a []string, n int) []string {
+	picked := make([]string, len(a))
+	copy(picked, a)
+	src.Shuffle(len(picked), func(i, j int) {
+		picked[i], picked[j] = picked[j], picked[i]
+	})
+	if len(picked) > n {
+		picked = picked[:n]
+	}
+	return picked
+}
+
+// titleCase capitalizes the first letter of each word in a string, except for
+// "of".
+func titleCase(s string) string {
+	words := strings.Split(s, " ")
+	for i, word := range words {
+		if word != "" && (i == 0 || word != "of") {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
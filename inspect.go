@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/jangler/oracles-randomizer/rom"
+)
+
+// inspectROM reads the item placements and other randomized data back out of
+// a randomized ROM, and prints them in the same form as the text spoiler log.
+func inspectROM(filename string, logf logFunc) error {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if !rom.IsAges(b) && !rom.IsSeasons(b) {
+		return fmt.Errorf("%s is not an oracles ROM", filename)
+	}
+	game := rom.GameAges
+	if rom.IsSeasons(b) {
+		game = rom.GameSeasons
+	}
+	if err := rom.CheckRegion(rom.GetRegion(b)); err != nil {
+		return fmt.Errorf("%s: %v", filename, err)
+	}
+	if rom.IsVanilla(b) {
+		return fmt.Errorf("%s is a vanilla ROM", filename)
+	}
+	ins := rom.NewContext(game).Inspect(b)
+
+	if ins.Version != "" {
+		logf("randomized by version %s", ins.Version)
+	} else {
+		logf("randomized by an unknown version")
+	}
+
+	// seed trees are listed separately, like in the spoiler log
+	slots := make([]string, 0, len(ins.Items))
+	trees := make([]string, 0)
+	for slot, item := range ins.Items {
+		if strings.HasSuffix(item, " tree seeds") {
+			trees = append(trees, slot)
+		} else {
+			slots = append(slots, slot)
+		}
+	}
+	sort.Strings(slots)
+	sort.Strings(trees)
+
+	logf("")
+	logf("-- items --")
+	logf("")
+	for _, slot := range slots {
+		logf("%-28s <- %s",
+			getNiceName(slot), getNiceName(ins.Items[slot]))
+	}
+	logf("")
+	logf("-- seed trees --")
+	logf("")
+	for _, slot := range trees {
+		logf("%-28s <- %s",
+			getNiceName(slot), getNiceName(ins.Items[slot]))
+	}
+
+	if game == rom.GameSeasons {
+		logf("")
+		logf("default seasons:")
+		logf("")
+		areas := make([]string, 0, len(ins.Seasons))
+		for area := range ins.Seasons {
+			areas = append(areas, area)
+		}
+		sort.Strings(areas)
+		for _, area := range areas {
+			season := "unknown"
+			if id := int(ins.Seasons[area]); id < len(seasonsByID) {
+				season = seasonsByID[id]
+			}
+			logf("%-15s <- %s", area, season)
+		}
+	}
+	logf("")
+	logf("animal companion <- %s", companionNames[ins.Companion])
+
+	if len(ins.Unknown) > 0 {
+		logf("")
+		logf("unrecognized data:")
+		logf("")
+		for _, s := range ins.Unknown {
+			logf(s)
+		}
+	}
+
+	return nil
+}
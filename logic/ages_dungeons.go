@@ -37,7 +37,7 @@ var agesD2Nodes = map[string]*Node{
 	"d2 moblin platform": AndSlot("d2 3 keys"),
 	// push moblin into doorway, stand on button, use switch hook
 	"d2 statue room": And("d2 moblin platform", Or("bracelet", "cane",
-		HardAnd("switch hook", "push enemy"))),
+		HardAnd(dungeonTricks, "switch hook", "push enemy"))),
 	"d2 color room": AndSlot("d2 all keys"),
 	"d2 essence":    AndStep("d2 all keys", "d2 boss key"),
 
@@ -66,7 +66,8 @@ var agesD3Nodes = map[string]*Node{
 	"d3 statue key":       And("d3 E crystal"),
 	// you can clip into the blocks enough to hit this crystal with switch hook
 	"d3 N crystal": And("d3 statue key",
-		Or("any seed shooter", "boomerang", Hard("switch hook"))),
+		Or("any seed shooter", "boomerang",
+			Hard(dungeonTricks, "switch hook"))),
 	"d3 armos key":        And("d3 statue key"),
 	"d3 bush beetle room": AndSlot("d3 armos key"),
 	"d3 W crystal":        And("d3 statue key"),
@@ -82,12 +83,14 @@ var agesD3Nodes = map[string]*Node{
 	"d3 crossroads":         AndSlot("d3 B1F spinner"),
 	"d3 conveyor belt room": AndSlot("d3 statue key"),
 	"d3 bridge chest": AndSlot("d3 statue key",
-		Or("any seed shooter", "jump 3", HardAnd("d3 all keys", "feather"),
+		Or("any seed shooter", "jump 3",
+			HardAnd(dungeonTricks, "d3 all keys", "feather"),
 			And("boomerang", Or("feather", "pegasus satchel")))),
 	"d3 torch chest": AndSlot("d3 B1F spinner",
-		Or("ember shooter", Hard("mystery shooter"))),
+		Or("ember shooter", Hard(mysteryTorches, "mystery shooter"))),
 	"kill subterror": And("shovel",
-		Or("sword", "switch hook", "scent seeds", Hard("bombs"))),
+		Or("sword", "switch hook", "scent seeds",
+			Hard(bombsAsWeapon, "bombs"))),
 	"d3 B1F east": AndSlot("d3 B1F spinner", "kill subterror",
 		"any seed shooter"),
 	"d3 block key": And("d3 B1F spinner", "kill subterror"),
@@ -95,9 +98,9 @@ var agesD3Nodes = map[string]*Node{
 	"d3 essence": AndStep("d3 boss key", "d3 all keys",
 		Or("ember seeds", "scent seeds"),
 		Or("seed shooter", And(
-			Or("ember seeds", Hard()),
-			Or("boomerang", Hard("jump 3"),
-				HardAnd("feather", "sword", "switch hook"))))),
+			Or("ember seeds", Hard(dungeonTricks)),
+			Or("boomerang", Hard(dungeonTricks, "jump 3"),
+				HardAnd(dungeonTricks, "feather", "sword", "switch hook"))))),
 }
 
 var agesD4Nodes = map[string]*Node{
@@ -106,17 +109,17 @@ var agesD4Nodes = map[string]*Node{
 	"d4 key chest A": And("d4 first chest", "feather"),
 	"d4 minecart A":  And("enter d4", "feather", "d4 key A"),
 	"d4 key chest B": And("d4 minecart A",
-		Or("any seed shooter", Hard("boomerang"))),
+		Or("any seed shooter", Hard(dungeonTricks, "boomerang"))),
 	"d4 minecart chest": AndSlot("d4 minecart A", "hit lever"),
 	"d4 minecart B": And("d4 minecart A", "hit lever",
 		"d4 key B", "bracelet", "kill stalfos"),
 	"d4 key chest C": And("d4 minecart B",
-		Or("any seed shooter", HardAnd("jump 3", "boomerang"))),
+		Or("any seed shooter", HardAnd(dungeonTricks, "jump 3", "boomerang"))),
 	"d4 minecart C": And("d4 minecart B", "d4 key C"),
 	"d4 minecart D": And("d4 minecart C", "d4 key D"),
 	// these weapons are for the miniboss, not the moldorms
 	"d4 small floor puzzle": AndSlot("d4 minecart D", "bombs",
-		Or("sword", "switch hook", "scent shooter", Hard())),
+		Or("sword", "switch hook", "scent shooter", Hard(dungeonTricks))),
 	"d4 key chest E":    And("d4 minecart D", "switch hook"),
 	"d4 lava pot chest": AndSlot("d4 key chest E", "d4 key E"),
 	"d4 essence": AndStep("d4 key chest E", "d4 boss key",
@@ -126,7 +129,8 @@ var agesD4Nodes = map[string]*Node{
 	"d4 key B": And("d4 key chest B"),
 	"d4 key C": And("d4 key chest C"),
 	"d4 key D": And("d4 minecart C", Or("sword", "ember seeds",
-		"scent shooter", "gale shooter", Hard("scent satchel"))),
+		"scent shooter", "gale shooter",
+		Hard(satchelAsWeapon, "scent satchel"))),
 	"d4 key E": And("d4 key chest E"),
 }
 
@@ -136,17 +140,19 @@ var agesD5Nodes = map[string]*Node{
 	"d5 switch A":       And("enter d5", "kill normal", "hit switch"),
 	"d5 blue peg chest": AndSlot("d5 switch A"),
 	"d5 dark chest": And("d5 switch A",
-		Or("cane", "switch hook", HardOr("kill normal", "push enemy"))),
+		Or("cane", "switch hook",
+			HardOr(dungeonTricks, "kill normal", "push enemy"))),
 	"d5 boxed chest": And("d5 switch A"),
 	"d5 eyes chest":  And("d5 switch A", "any seed shooter"),
 	"d5 2-statue chest": And("d5 switch A", "break pot", "cane", "feather",
-		Or("any seed shooter", "boomerang", HardAnd("feather", "sword"))),
+		Or("any seed shooter", "boomerang",
+			HardAnd(dungeonTricks, "feather", "sword"))),
 	"d5 essence": AndStep("d5 switch A", "d5 boss key", "cane", "sword"),
 
 	// require 1 small key minimum, 2 maximum.
 	// keys A (dark chest) and E (3-statue chest) are always available by now.
 	"d5 crossroads": And("d5 switch A", "feather", "bracelet",
-		Or("cane", Hard("jump 3"))),
+		Or("cane", Hard(dungeonTricks, "jump 3"))),
 	"d5 diamond chest": AndSlot("d5 crossroads", "switch hook"),
 
 	// require 1 small key minimum, 5 maximum.
@@ -203,9 +209,10 @@ var agesD6Nodes = map[string]*Node{
 		Or("flippers", "feather", "switch hook"),
 		Or("any seed shooter", "boomerang",
 			And("jump 3", Or("sword", "switch hook", "ember seeds",
-				"scent seeds", "mystery seeds", Hard("bombs"))))),
+				"scent seeds", "mystery seeds",
+				Hard(bombsAsWeapon, "bombs"))))),
 	"d6 present color room": And("d6 present hand room", "bombs",
-		"switch hook", Or("feather", Hard())),
+		"switch hook", Or("feather", Hard(dungeonTricks))),
 	"d6 present spinner chest": And("d6 past spinner", "d6 present hand room",
 		Or("feather", "switch hook")),
 	"d6 present beamos chest": AndSlot("enter d6 present", "d6 open wall",
@@ -217,7 +224,7 @@ var agesD6Nodes = map[string]*Node{
 	"d6 present channel chest": AndSlot("enter d6 present", "d6 open wall",
 		"d6 present all keys", "switch hook"),
 	"d6 present vire chest": AndSlot("d6 present spinner chest",
-		"d6 present all keys", Or("sword", Hard()), "switch hook"),
+		"d6 present all keys", Or("sword", Hard(dungeonTricks)), "switch hook"),
 
 	"d6 present key A": And("d6 present rope chest"),
 	"d6 present key B": And("d6 present color room"),
@@ -270,7 +277,8 @@ var agesD8Nodes = map[string]*Node{
 	"d8 group A": And("enter d8", "bombs"), // only has small key
 
 	"d8 group B": And("d8 group A", "switch hook", "cane",
-		"seed shooter", Or("ember seeds", Hard("mystery seeds"))), // +1 key
+		"seed shooter", // +1 key
+		Or("ember seeds", Hard(mysteryTorches, "mystery seeds"))),
 	"d8 isolated chest": AndSlot("d8 group B"),
 
 	"d8 group C":           And("d8 group B"), // +1 small key
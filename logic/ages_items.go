@@ -7,7 +7,7 @@ var agesItemNodes = map[string]*Node{
 	"noble sword": And("sword 1", "sword 2"),
 
 	"bombs": Or(And("bombs, 10", Or("break bush", "flute", "shovel")),
-		Hard("goron shooting gallery")),
+		Hard(altBombSources, "goron shooting gallery")),
 
 	"switch hook": Or("switch hook 1", "switch hook 2"),
 	"long hook":   And("switch hook 1", "switch hook 2"),
@@ -28,18 +28,24 @@ var agesItemNodes = map[string]*Node{
 
 	"satchel": Or("satchel 1", "satchel 2"),
 
+	// only placed if rings are shuffled
+	"fist ring":     Root(),
+	"expert's ring": Root(),
+	"punch":         Or("fist ring", "expert's ring"),
+
 	"flippers":     Or("flippers 1", "flippers 2"),
 	"mermaid suit": And("flippers 1", "flippers 2"),
 
-	"bomb jump 2": And("feather", Or("pegasus satchel", Hard("bombs"))),
+	"bomb jump 2": And("feather",
+		Or("pegasus satchel", Hard(bombJumps, "bombs"))),
 	"jump 3":      And("feather", "pegasus satchel"),
-	"bomb jump 3": HardAnd("feather", "pegasus satchel", "bombs"),
+	"bomb jump 3": HardAnd(bombJumps, "feather", "pegasus satchel", "bombs"),
 
 	"seed item": Or("satchel", "seed shooter"),
 
 	"ember seeds": And("ember tree seeds"),
 	"scent seeds": Or("scent tree seeds",
-		HardAnd("d3 E crystal", "seed item")),
+		HardAnd(altSeedSources, "d3 E crystal", "seed item")),
 	"pegasus seeds": And("pegasus tree seeds"),
 	"gale seeds":    And("gale tree seeds"),
 	"mystery seeds": And("mystery tree seeds"),
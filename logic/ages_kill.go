@@ -36,15 +36,16 @@ var agesKillNodes = map[string]*Node{
 	"break bush": Or("sword", "switch hook", "bracelet"),
 
 	"satchel weapon": And("satchel",
-		Or("ember seeds", HardOr("scent seeds", "gale seeds"))),
+		Or("ember seeds",
+			HardOr(satchelAsWeapon, "scent seeds", "gale seeds"))),
 	"shooter weapon": And("seed shooter",
 		Or("ember seeds", "scent seeds", "gale seeds")),
 
 	// most enemies are vulnerable to these items
 	"kill normal": Or("sword", "satchel weapon", "shooter weapon", "cane",
-		Hard("bombs")),
+		Hard(bombsAsWeapon, "bombs"), Hard(ringPunches, "punch")),
 	"kill normal ranged": Or("shooter weapon", And("cane", "bracelet"),
-		Hard("bombs")),
+		Hard(bombsAsWeapon, "bombs")),
 	"kill underwater": Or("sword", "shooter weapon"),
 
 	"kill gel":     Or("kill normal", "switch hook", "boomerang", "shovel"),
@@ -52,18 +53,20 @@ var agesKillNodes = map[string]*Node{
 	"kill zol":     Or("kill normal", "switch hook"),
 	"kill ghini":   Or("kill normal", "switch hook"),
 	"kill giant ghini": Or("sword", "scent shooter", "switch hook",
-		HardOr("bombs", "scent satchel")),
+		Hard(bombsAsWeapon, "bombs"), Hard(satchelAsWeapon, "scent satchel")),
 	"kill pumpkin head": And("bracelet",
 		Or("sword", "ember seeds", "scent shooter",
-			HardOr("bombs", "scent satchel"))),
+			Hard(bombsAsWeapon, "bombs"),
+			Hard(satchelAsWeapon, "scent satchel"))),
 
-	"kill spiked beetle": Or("gale shooter", Hard("gale satchel"),
+	"kill spiked beetle": Or("gale shooter",
+		Hard(satchelAsWeapon, "gale satchel"),
 		And(Or("shield", "shovel"), Or("kill normal", "switch hook"))),
 	"kill swoop": Or("sword", "scent shooter", "switch hook",
-		HardOr("bombs", "scent satchel")),
+		Hard(bombsAsWeapon, "bombs"), Hard(satchelAsWeapon, "scent satchel")),
 
 	"kill moldorm": Or("sword", "scent shooter", "cane", "switch hook",
-		HardOr("bombs", "scent satchel")),
+		Hard(bombsAsWeapon, "bombs"), Hard(satchelAsWeapon, "scent satchel")),
 
 	"kill wizzrobe": Or("kill normal"),
 }
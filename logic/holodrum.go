@@ -11,7 +11,8 @@ var holodrumNodes = map[string]*Node{
 	"horon village": And("start"),
 	"maku tree":     AndSlot("horon village", "sword"),
 	"horon village seed tree": AndSlot("horon village", "seed item",
-		Or("harvest tree", "dimitri's flute", Hard("remove bush"))),
+		Or("harvest tree", "dimitri's flute",
+			Hard(altSeedSources, "remove bush"))),
 	"horon village SE chest": AndSlot("horon village", "bombs"),
 	"horon village SW chest": AndSlot("horon village",
 		Or("remove mushroom", "dimitri's flute")),
@@ -26,7 +27,7 @@ var holodrumNodes = map[string]*Node{
 	//
 	// possible but not in logic: reaching the stump without feather
 	"black beast's chest": AndSlot("horon village",
-		Or("ember slingshot", Hard("mystery slingshot")),
+		Or("ember slingshot", Hard(mysteryTorches, "mystery slingshot")),
 		"mystery seeds", "kill moldorm"),
 	"enter d0":    And("horon village"),
 	"pirate ship": And("pirate's bell", "pirate house"),
@@ -143,7 +144,8 @@ var holodrumNodes = map[string]*Node{
 	"spool swamp cave": AndSlot("south swamp",
 		Or("spool swamp default winter", And("spool stump", "winter")),
 		Or("shovel", "flute"), Or("bombs", "ricky's flute")),
-	"enter d3": And("spool stump", Or("spool swamp default summer", "summer")),
+	"enter d3": And("spool stump",
+		Or("spool swamp default summer", "summer")),
 
 	// north horon / eyeglass lake
 	"not north horon default summer": Or("north horon default spring",
@@ -231,7 +233,7 @@ var holodrumNodes = map[string]*Node{
 		Or("sword", "fool's ore")),
 	"moosh": And("mount cucco", "spring banana"),
 	"goron mountain, across pits": AndSlot("mount cucco",
-		Or("moosh", "jump 6", Hard("jump 4"))),
+		Or("moosh", "jump 6", Hard(tightJumps, "jump 4"))),
 	"mt. cucco, talon's cave": AndSlot("mount cucco",
 		Or("sunken city default spring", "spring")),
 	"dragon keyhole": And("mt. cucco, talon's cave",
@@ -16,7 +16,7 @@ var labrynnaNodes = map[string]*Node{
 	"maku tree": OrSlot("rescue nayru",
 		And("lynna village", "shovel", "kill normal")),
 	"south lynna tree": AndSlot("lynna city", "seed item",
-		Or("sword", "dimitri's flute", Hard("break bush"))),
+		Or("sword", "dimitri's flute", Hard(altSeedSources, "break bush"))),
 	"lynna city chest": OrSlot("ember seeds", "currents"),
 	"shore present": Or("flute", "ricky's gloves",
 		And("break bush", "feather"), And("lynna city", "bracelet"),
@@ -41,7 +41,7 @@ var labrynnaNodes = map[string]*Node{
 	"shop, 150 rupees":   AndSlot("lynna city"),
 	"ambi's palace tree": AndSlot("lynna village", "sword", "seed item"),
 	"ambi's palace chest": AndSlot("lynna village", Or("ages",
-		HardAnd("satchel", "scent seeds", "pegasus seeds"),
+		HardAnd(guardSkip, "satchel", "scent seeds", "pegasus seeds"),
 		And("break bush safe", "mermaid suit"))),
 	"rescue nayru": AndSlot("ambi's palace chest", "mystery seeds",
 		"switch hook", "sword"), // fight is scripted; only sword ends it
@@ -74,7 +74,7 @@ var labrynnaNodes = map[string]*Node{
 		Or("feather", "switch hook", "ember seeds", "ages", "gale satchel")),
 	"deku forest tree": AndSlot("deku forest", "sword", "seed item",
 		Or("ember seeds", "ages", "switch hook", "gale satchel",
-			Hard("feather"))),
+			Hard(tightJumps, "feather"))),
 	"deku forest soldier": AndSlot("deku forest", "mystery seeds"),
 	"enter d2":            And("deku forest", Or("bombs", "currents")),
 
@@ -114,7 +114,8 @@ var labrynnaNodes = map[string]*Node{
 	// placing a block on the button allows infinite time to pit the beetles
 	"patch": And("restoration wall", Or("sword",
 		And("cane", Or("shield", "boomerang", "switch hook", "scent seeds")),
-		HardOr("shield", "boomerang", "switch hook", "scent seeds"))),
+		HardOr(patchNoWeapon,
+			"shield", "boomerang", "switch hook", "scent seeds"))),
 	"talus peaks chest": OrSlot("restoration wall"),
 	"enter d4":          And("symmetry present", "tuni nut", "patch"),
 
@@ -159,10 +160,12 @@ var labrynnaNodes = map[string]*Node{
 		And("lynna city", Or("feather", "ages"), "mermaid suit"),
 		And("ridge mid past", "feather", "brother emblem"),
 		And("ridge mid present", "ages"),
-		And("ridge base past west", Or("flippers", Hard("jump 3")))),
+		And("ridge base past west",
+			Or("flippers", Hard(tightJumps, "jump 3")))),
 	"ridge base past west": Or(
 		And("ridge base present", "echoes"),
-		And("ridge base past east", Or("flippers", Hard("jump 3")))),
+		And("ridge base past east",
+			Or("flippers", Hard(tightJumps, "jump 3")))),
 	"ridge base past":     AndSlot("ridge base past west", "bombs"),
 	"enter d6 past":       And("mermaid key", "ridge base past west"),
 	"ridge diamonds past": AndSlot("ridge base past west", "switch hook"),
@@ -192,7 +195,7 @@ var labrynnaNodes = map[string]*Node{
 	"zora NW cave":         AndSlot("zora village", "bombs", "power glove"),
 	"fairies' coast chest": AndSlot("zora village"),
 	// in hard logic, farm kills and get a potion off maple
-	"king zora":       AndSlot("zora village", Or("syrup", Hard())),
+	"king zora":       AndSlot("zora village", Or("syrup", Hard(maplePotion))),
 	"library present": AndSlot("zora village", "library key"),
 	"library past": AndSlot("zora village", "library key",
 		Or("book of seals", "bomb jump 3")),
@@ -206,6 +209,6 @@ var labrynnaNodes = map[string]*Node{
 	"piratian captain":   AndSlot("lynna city", "mermaid suit", "zora scale"),
 	"sea of storms past": AndSlot("lynna city", "mermaid suit", "zora scale"),
 	"enter d8": And("crescent past", "tokay eyeball", "kill normal", "break pot",
-		"bombs", Or("cane", Hard()), "mermaid suit", "feather"),
+		"bombs", Or("cane", Hard(dungeonTricks)), "mermaid suit", "feather"),
 	"sea of no return": AndSlot("enter d8", "power glove"),
 }
@@ -20,7 +20,8 @@ type Type int
 //
 // "Hard" nodes are ones that players aren't expected to do because they're too
 // difficult or esoteric, but they're used to prevent softlocks by knowing that
-// players *can* do them.
+// players *can* do them. Each one is tagged with the name of a trick, and is
+// only satisfied if that trick is enabled.
 //
 // The following functions are half syntactic sugar for declaring large lists
 // of node relationships.
@@ -32,8 +33,6 @@ const (
 	OrSlotType
 	AndStepType
 	OrStepType
-	HardAndType
-	HardOrType
 )
 
 // A Node is a mapping of strings that will become And or Or nodes in the
@@ -41,6 +40,7 @@ const (
 type Node struct {
 	Parents []interface{}
 	Type    Type
+	Trick   string // required trick, if any
 }
 
 // CreateFunc returns a function that creates graph nodes from a list of key
@@ -61,11 +61,19 @@ var (
 	Or      = CreateFunc(OrType)
 	OrSlot  = CreateFunc(OrSlotType)
 	OrStep  = CreateFunc(OrStepType)
-	Hard    = CreateFunc(HardAndType) // for wrapping single nodes
-	HardAnd = CreateFunc(HardAndType)
-	HardOr  = CreateFunc(HardOrType)
+	Hard    = HardAnd // for wrapping single nodes
 )
 
+// HardAnd returns an And node that requires the named trick.
+func HardAnd(trick string, parents ...interface{}) *Node {
+	return &Node{Parents: parents, Type: AndType, Trick: trick}
+}
+
+// HardOr returns an Or node that requires the named trick.
+func HardOr(trick string, parents ...interface{}) *Node {
+	return &Node{Parents: parents, Type: OrType, Trick: trick}
+}
+
 var seasonsNodes, agesNodes map[string]*Node
 
 func init() {
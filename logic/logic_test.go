@@ -20,10 +20,10 @@ func isParent(p1Name string, p2 *Node) bool {
 func TestLinks(t *testing.T) {
 	// need to be changed manually for now
 	nodes := GetAges()
-	rom.Init(rom.GameAges)
+	ctx := rom.NewContext(rom.GameAges)
 
-	for key, slot := range rom.ItemSlots {
-		treasureName := rom.FindTreasureName(slot.Treasure)
+	for key, slot := range ctx.ItemSlots {
+		treasureName := ctx.FindTreasureName(slot.Treasure)
 		if node, ok := nodes[treasureName]; ok {
 			node.Parents = append(node.Parents, key)
 		} else {
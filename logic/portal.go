@@ -21,16 +21,18 @@ var portalNodes = map[string]*Node{
 		And(Or("north horon default winter", "winter"), "jump 6")))),
 
 	"village portal": Or(
-		And("horon village", Or("boomerang L-2", Hard("jump 6"))),
+		And("horon village", Or("boomerang L-2", Hard(tightJumps, "jump 6"))),
 		And("pirate house", "hit lever")),
 
 	// effectively one-way
 	"remains portal": And("temple remains",
 		Or("temple remains default winter", "winter"), Or(
-			HardAnd("shovel", "remove bush", "jump 6"),
-			HardAnd(Or("temple remains default spring", "spring"),
+			HardAnd(remainsNoAutumn, "shovel", "remove bush", "jump 6"),
+			HardAnd(remainsNoAutumn,
+				Or("temple remains default spring", "spring"),
 				"remove flower", "remove bush", "jump 6", "winter"),
-			HardAnd(Or("temple remains default summer", "summer"),
+			HardAnd(remainsNoAutumn,
+				Or("temple remains default summer", "summer"),
 				"remove bush", "jump 6", "winter"),
 			And(Or("temple remains default autumn", "autumn"),
 				"remove bush", "jump 2", "winter"))),
@@ -0,0 +1,117 @@
+package logic
+
+import "fmt"
+
+// regions group overworld item slots for hints, following the sections of the
+// overworld logic files. dungeon slots are grouped by dungeon instead.
+var seasonsRegions = map[string][]string{
+	"horon village": {"maku tree", "horon village seed tree",
+		"horon village SE chest", "horon village SW chest", "shop, 20 rupees",
+		"shop, 30 rupees", "shop, 150 rupees", "member's shop 1",
+		"member's shop 2", "member's shop 3", "black beast's chest"},
+	"western coast": {"western coast, beach chest",
+		"western coast, in house"},
+	"woods of winter": {"holly's house", "woods of winter seed tree",
+		"chest on top of D2", "cave outside D2", "woods of winter, 1st cave",
+		"woods of winter, 2nd cave"},
+	"eastern suburbs": {"eastern suburbs, on cliff"},
+	"north horon": {"north horon seed tree", "blaino prize",
+		"old man in treehouse", "cave south of mrs. ruul",
+		"cave north of D1"},
+	"eyeglass lake": {"eyeglass lake, across bridge",
+		"dry eyeglass lake, east cave", "dry eyeglass lake, west cave"},
+	"spool swamp": {"spool swamp seed tree",
+		"floodgate keeper's house", "spool swamp cave",
+		"diving spot outside D4"},
+	"natzu": {"moblin keep", "natzu region, across water"},
+	"sunken city": {"sunken city seed tree",
+		"master diver's challenge", "master diver's reward",
+		"sunken city, summer cave", "chest in master diver's cave"},
+	"mt. cucco": {"spring banana tree", "mt. cucco, talon's cave",
+		"goron mountain, across pits", "chest in goron mountain"},
+	"lost woods":    {"lost woods"},
+	"tarm ruins":    {"tarm ruins seed tree", "tarm ruins, under tree"},
+	"samasa desert": {"samasa desert chest", "samasa desert pit"},
+	"subrosia": {"subrosian dance hall", "subrosian wilds chest",
+		"subrosia village chest", "subrosia market, 1st item",
+		"subrosia market, 2nd item", "subrosia market, 5th item",
+		"great furnace", "subrosian smithy", "subrosia seaside",
+		"subrosia, locked cave", "subrosia, open cave", "temple of seasons",
+		"tower of spring", "tower of summer", "tower of autumn",
+		"tower of winter"},
+}
+
+var agesRegions = map[string][]string{
+	"forest of time": {"starting chest", "nayru's house"},
+	"lynna": {"black tower worker", "maku tree", "south lynna tree",
+		"lynna city chest", "south shore dirt", "balloon guy's gift",
+		"balloon guy's upgrade", "shop, 30 rupees", "shop, 150 rupees",
+		"ambi's palace tree", "ambi's palace chest", "rescue nayru",
+		"mayor plen's house"},
+	"yoll graveyard": {"cheval's test", "cheval's invention",
+		"grave under tree", "graveyard poe"},
+	"western woods": {"fairies' woods chest", "deku forest cave east",
+		"deku forest cave west", "deku forest tree", "deku forest soldier"},
+	"crescent island": {"tokay crystal cave", "tokay bomb cave",
+		"wild tokay game", "crescent island tree", "hidden tokay cave",
+		"under crescent island", "tokay pot cave"},
+	"symmetry city": {"nuun highlands cave", "symmetry city tree",
+		"symmetry city brother", "tokkey's composition", "talus peaks chest"},
+	"rolling ridge": {"goron elder", "ridge west cave",
+		"rolling ridge west tree", "under moblin keep", "defeat great moblin",
+		"pool in d6 entrance", "goron dance present",
+		"goron dance, with letter", "target carts 1", "target carts 2",
+		"goron shooting gallery", "rolling ridge east tree", "ridge base past",
+		"ridge diamonds past", "bomb goron head", "big bang game",
+		"ridge NE cave present", "trade rock brisket", "trade goron vase",
+		"trade lava juice", "goron's hiding place", "ridge base chest",
+		"goron diamond cave", "ridge bush cave"},
+	"zora seas": {"zora village tree", "zora village present",
+		"zora palace chest", "zora NW cave", "fairies' coast chest",
+		"king zora", "library present", "library past", "zora seas chest",
+		"fisher's island cave", "zora's reward"},
+	"sea of storms": {"piratian captain", "sea of storms past",
+		"sea of no return"},
+}
+
+var seasonsDungeonNames = []string{"hero's cave", "gnarled root dungeon",
+	"snake's remains", "poison moth's lair", "dancing dragon dungeon",
+	"unicorn's cave", "ancient ruins", "explorer's crypt",
+	"sword & shield maze"}
+
+var agesDungeonNames = []string{"maku path", "spirit's grave",
+	"wing dungeon", "moonlit grotto", "skull dungeon", "crown dungeon",
+	"mermaid's cave", "jabu-jabu's belly", "ancient tomb"}
+
+// SeasonsRegions returns a map of seasons slot names to hint region names.
+// dungeon slots are included if given.
+func SeasonsRegions(slots []string) map[string]string {
+	return getRegions(slots, seasonsRegions, seasonsDungeonNames)
+}
+
+// AgesRegions returns a map of ages slot names to hint region names. dungeon
+// slots are included if given.
+func AgesRegions(slots []string) map[string]string {
+	return getRegions(slots, agesRegions, agesDungeonNames)
+}
+
+func getRegions(slots []string, overworld map[string][]string,
+	dungeons []string) map[string]string {
+	regions := make(map[string]string, len(slots))
+	for region, names := range overworld {
+		for _, name := range names {
+			regions[name] = region
+		}
+	}
+
+	// dungeon slots start with "dN "
+	for _, name := range slots {
+		var i int
+		if _, err := fmt.Sscanf(name, "d%d ", &i); err == nil &&
+			i < len(dungeons) {
+			regions[name] = dungeons[i]
+		}
+	}
+
+	return regions
+}
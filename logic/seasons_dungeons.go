@@ -1,18 +1,27 @@
 package logic
 
 // keep small keys and their chests separate, so that they can be changed into
-// slots if small keys are ever randomized.
+// slots if small keys are randomized.
 //
 // dungeons should rely on overworld information as little as possible.
 // ideally "enter <dungeon>" is the only overworld item the dungeon nodes
 // reference (and that node should not be defined here).
 
+// SeasonsKeyChests returns a map of small key chests that can be slots to the
+// key nodes that depend on them. only the hero's cave is included, since the
+// other dungeons also have keys that drop or fall, which aren't slots.
+func SeasonsKeyChests() map[string]string {
+	return map[string]string{
+		"d0 key chest": "d0 key A",
+	}
+}
+
 var seasonsD0Nodes = map[string]*Node{
 	"d0 key chest":   And("enter d0"),
-	"d0 sword chest": AndSlot("enter d0", "d0 small key"),
+	"d0 sword chest": AndSlot("enter d0", "d0 key A"),
 	"d0 rupee chest": OrSlot("remove bush safe", "flute"),
 
-	"d0 small key": And("d0 key chest"),
+	"d0 key A": And("d0 key chest"),
 }
 
 var seasonsD1Nodes = map[string]*Node{
@@ -25,9 +34,10 @@ var seasonsD1Nodes = map[string]*Node{
 	"enter goriya bros":     And("d1 railway chest", "bombs", "d1 key B"),
 	"d1 basement":           AndSlot("enter goriya bros", "kill goriya bros"),
 	"d1 goriya chest": AndSlot("d1 stalfos chest",
-		Or("ember seeds", Hard("mystery seeds")), "kill goriya (pit)"),
+		Or("ember seeds", Hard(mysteryTorches, "mystery seeds")),
+		"kill goriya (pit)"),
 	"d1 floormaster room": AndSlot("enter d1",
-		Or("ember seeds", Hard("mystery seeds"))),
+		Or("ember seeds", Hard(mysteryTorches, "mystery seeds"))),
 	"d1 essence": AndStep("d1 floormaster room", "d1 boss key",
 		"kill aquamentus"),
 
@@ -39,7 +49,8 @@ var seasonsD2Nodes = map[string]*Node{
 	"d2 left from entrance": AndSlot("d2 torch room"),
 	"d2 rope room":          And("d2 torch room", "kill rope"),
 	"d2 arrow room": Or("enter d2 B",
-		And("d2 torch room", Or("ember seeds", Hard("mystery seeds")))),
+		And("d2 torch room",
+			Or("ember seeds", Hard(mysteryTorches, "mystery seeds")))),
 	"d2 rupee room":    And("d2 arrow room", "bombs"),
 	"d2 hardhat room":  And("d2 arrow room", "d2 2 keys"), // min. 1 key
 	"d2 pot chest":     AndSlot("d2 hardhat room", "remove pot"),
@@ -119,8 +130,9 @@ var seasonsD4Nodes = map[string]*Node{
 
 	// B1F
 	"enter gohma": And("d4 basement stairs", "d4 boss key",
-		Or("ember slingshot", Hard("mystery slingshot"), "jump 3",
-			HardAnd("jump 2", Or("ember seeds", "mystery seeds")))),
+		Or("ember slingshot", Hard(mysteryTorches, "mystery slingshot"),
+			"jump 3", HardAnd(jumpingSeedTosses, "jump 2",
+				Or("ember seeds", "mystery seeds")))),
 	"d4 essence": AndStep("enter gohma", "kill gohma"),
 
 	// fixed items
@@ -145,10 +157,11 @@ var seasonsD5Nodes = map[string]*Node{
 	"d5 cart bay":   And("enter d5", Or("flippers", "bomb jump 2")),
 	"d5 cart chest": And("d5 cart bay", "hit lever"),
 	"d5 pot room": And("enter d5", Or(And("magnet gloves", "bombs", "jump 2"),
-		And("d5 cart bay", Or("jump 2", Hard("pegasus satchel"))))),
+		And("d5 cart bay",
+			Or("jump 2", Hard(dungeonTricks, "pegasus satchel"))))),
 	"d5 gibdo/zol chest": AndSlot("d5 pot room", "kill gibdo", "kill zol"),
 	"d5 magnet ball chest": AndSlot("d5 pot room",
-		Or("flippers", "jump 6", Hard("jump 4")), "d5 5 keys"),
+		Or("flippers", "jump 6", Hard(tightJumps, "jump 4")), "d5 5 keys"),
 	"d5 left chest": And("enter d5", Or("magnet gloves", "jump 4")),
 	"d5 terrace chest": AndSlot("enter d5", Or("magnet gloves",
 		And("d5 cart bay", "jump 2", "bombs"))),
@@ -161,9 +174,10 @@ var seasonsD5Nodes = map[string]*Node{
 	"d5 pre-mid chest": And("d5 cart bay", Or("magnet gloves", "jump 4")),
 	"d5 post-syger":    And("d5 pre-mid chest", "kill syger"), // keys after
 	"d5 basement": AndSlot("d5 drop ball", "d5 post-syger",
-		"magnet gloves", Or("kill magunesu", Hard("jump 2")), "d5 5 keys"),
+		"magnet gloves", Or("kill magunesu", Hard(dungeonTricks, "jump 2")),
+		"d5 5 keys"),
 	"d5 essence": AndStep("d5 post-syger", "magnet gloves",
-		Or("jump 2", Hard("start")), "d5 boss key", "d5 5 keys"),
+		Or("jump 2", Hard(dungeonTricks, "start")), "d5 boss key", "d5 5 keys"),
 
 	// fixed items
 	"d5 key A": And("d5 cart chest"),
@@ -219,19 +233,19 @@ var seasonsD7Nodes = map[string]*Node{
 	"d7 wizzrobe chest":    And("enter d7", "kill wizzrobe"),
 	"d7 right of entrance": AndSlot("enter d7", "d7 key A"),
 	"enter poe A": And("d7 right of entrance",
-		Or("ember slingshot", Hard("mystery slingshot"))),
+		Or("ember slingshot", Hard(mysteryTorches, "mystery slingshot"))),
 	"d7 bombed wall chest": AndSlot("enter d7", "bombs"),
 	"d7 quicksand chest":   AndSlot("d7 pot room", "jump 2", "d7 key B"),
 
 	// B1F
 	"d7 pot room": And("enter d7", "bracelet", Or(
 		And("enter poe A", "kill poe sister"),
-		HardAnd("magnet gloves", "jump 2", "pegasus satchel"))),
+		HardAnd(poeSkip, "magnet gloves", "jump 2", "pegasus satchel"))),
 	"d7 zol button": And("d7 pot room", "jump 2"),
 	"d7 magunesu chest": And("d7 armos puzzle", "jump 3", "kill magunesu",
 		"magnet gloves"),
 	"enter poe B": And("d7 pot room", "d7 3 keys", "ember seeds",
-		Or("pegasus satchel", "slingshot L-2", Hard("start"))),
+		Or("pegasus satchel", "slingshot L-2", Hard(poeTorches, "start"))),
 	"d7 water stairs": And("enter poe B", "flippers"),
 	"d7 spike chest":  AndSlot("d7 water stairs", "d7 cross bridge"),
 
@@ -265,20 +279,20 @@ var seasonsD7Nodes = map[string]*Node{
 var seasonsD8Nodes = map[string]*Node{
 	// 1F
 	"d8 eye room": And("enter d8", "remove pot", Or("any slingshot",
-		HardAnd("jump 2",
+		HardAnd(jumpingSeedTosses, "jump 2",
 			Or("ember satchel", "scent satchel", "mystery satchel")))),
 	"d8 three eyes chest": AndSlot("enter d8", "any slingshot L-2", "jump 2"),
 	"d8 hardhat room":     And("enter d8", "kill magunesu"),
 	"d8 hardhat key":      And("d8 hardhat room", "kill hardhat (magnet)"),
 	"d8 spike room": AndSlot("d8 hardhat room", "d8 1 key",
-		Or("jump 4", Hard("jump 3"))),
+		Or("jump 4", Hard(tightJumps, "jump 3"))),
 	"d8 magnet ball room": AndSlot("d8 spinner"),
 	"d8 bomb chest": And("d8 armos chest", "any slingshot L-2", "bombs",
 		"kill darknut"),
 	"d8 ice puzzle room": And("d8 armos chest", "kill frypolar", "ember seeds",
 		"slingshot L-2"),
 	"d8 pols voice chest": AndSlot("d8 ice puzzle room",
-		Or("jump 6", "boomerang L-2", Hard("start"))),
+		Or("jump 6", "boomerang L-2", Hard(dungeonTricks, "start"))),
 	"d8 crystal room": And("d8 ice puzzle room", "d8 4 keys"),
 	"d8 ghost armos":  And("d8 crystal room"),
 	"d8 NW crystal":   And("d8 crystal room", "d8 7 keys"),
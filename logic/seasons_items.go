@@ -17,13 +17,9 @@ var seasonsItemNodes = map[string]*Node{
 	"dimitri's flute": Root(),
 	"moosh's flute":   Root(),
 
-	// not actually placed
-	"fist ring":      Root(),
-	"expert's ring":  Root(),
-	"toss ring":      Root(),
-	"energy ring":    Root(),
-	"light ring L-1": Root(),
-	"light ring L-2": Root(),
+	// only placed if rings are shuffled
+	"fist ring":     Root(),
+	"expert's ring": Root(),
 
 	"sword L-1":     Or("sword 1", "sword 2"),
 	"sword L-2":     And("sword 1", "sword 2"),
@@ -38,19 +34,22 @@ var seasonsItemNodes = map[string]*Node{
 	// this of course doesn't apply to all trees, but trees won't have any
 	// seeds attached to them unless they can be harvested. so it works out.
 	"refill seeds": Or("harvest tree", "dimitri's flute", "dimitri",
-		Hard("remove bush")),
+		Hard(altSeedSources, "remove bush")),
 
 	"harvest ember seeds": And("seed item", Or(
-		And("ember tree seeds", "refill seeds"), Hard("d5 armos chest"),
-		HardAnd("harvest bush", Or("enter agunima", "enter d7")))),
+		And("ember tree seeds", "refill seeds"),
+		Hard(altSeedSources, "d5 armos chest"),
+		HardAnd(altSeedSources, "harvest bush",
+			Or("enter agunima", "enter d7")))),
 	"harvest mystery seeds": And("seed item", Or(
 		And("mystery tree seeds", "refill seeds"),
-		HardAnd("d8 armos chest", "harvest bush"))),
+		HardAnd(altSeedSources, "d8 armos chest", "harvest bush"))),
 	"harvest scent seeds": And("scent tree seeds",
 		"seed item", "refill seeds"),
 	"harvest pegasus seeds": And("seed item", Or(
 		And("pegasus tree seeds", "refill seeds"),
-		HardAnd("beach", "shield", "ore chunks", "seed item"))), // market
+		HardAnd(altSeedSources, // market
+			"beach", "shield", "ore chunks", "seed item"))),
 	"harvest gale seeds": And("gale tree seeds",
 		"seed item", "refill seeds"),
 
@@ -89,9 +88,10 @@ var seasonsItemNodes = map[string]*Node{
 	"slingshot": Or("slingshot L-1", "slingshot L-2"),
 	"seed item": Or("satchel", "slingshot"),
 	"kill for bombs": Or("sword", "ember seeds",
-		Or("scent slingshot", Hard("scent seeds")), "fool's ore"),
-	"bombs": Or(Hard("enter d2 B"),
-		HardAnd("harvest bush", "d2 bracelet room"),
+		Or("scent slingshot", Hard(satchelAsWeapon, "scent seeds")),
+		"fool's ore"),
+	"bombs": Or(Hard(altBombSources, "enter d2 B"),
+		HardAnd(altBombSources, "harvest bush", "d2 bracelet room"),
 		And("bombs, 10", Or("remove pot", "shovel", "remove flower", "flute",
 			And("kill for bombs", Or("suburbs", "fairy fountain",
 				And("mount cucco", Or("spring",
@@ -100,16 +100,18 @@ var seasonsItemNodes = map[string]*Node{
 	// jump x pit tiles
 	"jump 2":      Or("feather L-1", "feather L-2"),
 	"jump 3":      Or(And("feather L-1", "pegasus satchel"), "feather L-2"),
-	"bomb jump 2": Or("jump 3", HardAnd("jump 2", "bombs")),
-	"bomb jump 3": Or("jump 4", HardAnd("jump 3", "bombs")),
+	"bomb jump 2": Or("jump 3", HardAnd(bombJumps, "jump 2", "bombs")),
+	"bomb jump 3": Or("jump 4", HardAnd(bombJumps, "jump 3", "bombs")),
 	"jump 4":      And("feather L-2"),
-	"bomb jump 4": Or("jump 6", HardAnd("jump 4", "bombs")),
+	"bomb jump 4": Or("jump 6", HardAnd(bombJumps, "jump 4", "bombs")),
 	"jump 6":      And("feather L-2", "pegasus satchel"),
 
+	"punch": Or("fist ring", "expert's ring"),
+
 	"harvest tree": Or("sword", "rod", "fool's ore"),
 	"harvest bush": Or("sword", "bombs", "fool's ore"),
 
 	// technically the player can always get ore chunks if they can make it to
 	// subrosia, but shovel is the only way that isn't annoying.
-	"ore chunks": Or("shovel", Hard("start")),
+	"ore chunks": Or("shovel", Hard(oreChunkFarming, "start")),
 }
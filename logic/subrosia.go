@@ -22,9 +22,9 @@ var subrosiaNodes = map[string]*Node{
 
 	"beach": Or("swamp portal",
 		And("hide and seek", "jump 2", "bracelet",
-			Or("jump 3", "magnet gloves", Hard("bombs"))),
+			Or("jump 3", "magnet gloves", Hard(bombJumps, "bombs"))),
 		And("furnace", "bracelet", "jump 2"),
-		And("furnace", Or("jump 4", Hard("jump 3"))),
+		And("furnace", Or("jump 4", Hard(tightJumps, "jump 3"))),
 		And("furnace", "jump 2", "magnet gloves"),
 		And("temple", "jump 2")),
 
@@ -35,7 +35,7 @@ var subrosiaNodes = map[string]*Node{
 	"pirate house": Or("village portal", And("hide and seek", "jump 2")),
 
 	"furnace": Or("lake portal",
-		And("beach", Or("jump 4", Hard("jump 3"))),
+		And("beach", Or("jump 4", Hard(tightJumps, "jump 3"))),
 		And("beach", "magnet gloves", "jump 2")),
 
 	"bridge": Or(
@@ -0,0 +1,61 @@
+package logic
+
+import (
+	"sort"
+)
+
+// names of tricks, which are plays outside normal logic that can be enabled
+// one by one.
+const (
+	bombsAsWeapon       = "bombs as weapon"
+	bombJumps           = "bomb jumps"
+	satchelAsWeapon     = "satchel as weapon"
+	mysteryTorches      = "mystery seed torches"
+	jumpingSeedTosses   = "jumping seed tosses"
+	tightJumps          = "tight jumps"
+	altSeedSources      = "alternate seed sources"
+	altBombSources      = "alternate bomb sources"
+	oreChunkFarming     = "ore chunk farming"
+	frypolarNoSlingshot = "frypolar without slingshot"
+	poeSkip             = "poe skip"
+	poeTorches          = "poe torches without pegasus"
+	remainsNoAutumn     = "remains portal without autumn"
+	dungeonTricks       = "dungeon tricks"
+	guardSkip           = "guard skip"
+	patchNoWeapon       = "patch without sword or cane"
+	maplePotion         = "maple potion"
+	ringPunches         = "punching with rings"
+
+	// ShovelManip isn't attached to any node; it's checked by the router when
+	// deciding whether the player can afford something.
+	ShovelManip = "100-rupee shovel manip"
+)
+
+// SeasonsTricks returns the sorted names of all tricks that affect seasons
+// logic.
+func SeasonsTricks() []string {
+	return getTricks(seasonsNodes)
+}
+
+// AgesTricks returns the sorted names of all tricks that affect ages logic.
+func AgesTricks() []string {
+	return getTricks(agesNodes)
+}
+
+// returns the sorted names of the tricks used by the nodes, plus the ones that
+// aren't attached to nodes.
+func getTricks(nodes map[string]*Node) []string {
+	set := map[string]bool{ShovelManip: true}
+	for _, pn := range nodes {
+		if pn.Trick != "" {
+			set[pn.Trick] = true
+		}
+	}
+
+	tricks := make([]string, 0, len(set))
+	for trick := range set {
+		tricks = append(tricks, trick)
+	}
+	sort.Strings(tricks)
+	return tricks
+}
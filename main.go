@@ -7,10 +7,14 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/jangler/oracles-randomizer/graph"
+	"github.com/jangler/oracles-randomizer/logic"
+	"github.com/jangler/oracles-randomizer/patch"
 	"github.com/jangler/oracles-randomizer/rom"
 	"github.com/jangler/oracles-randomizer/ui"
 )
@@ -44,33 +48,52 @@ func fatal(err error, logf logFunc) {
 
 // options specified on the command line or via the TUI
 var (
-	flagHard     bool
-	flagN        int
-	flagNoMusic  bool
-	flagNoUI     bool
-	flagSeed     string
-	flagStats    string
-	flagTreewarp bool
-	flagVerbose  bool
+	flagApply     string
+	flagBossKeys  string
+	flagCompasses string
+	flagExpand    bool
+	flagRings     bool
+	flagHard      bool
+	flagHints     bool
+	flagInspect   string
+	flagD0Key     string
+	flagMaps      string
+	flagN         int
+	flagNoMusic   bool
+	flagNoUI      bool
+	flagOutput    string
+	flagPatchMap  bool
+	flagPlan      string
+	flagPreset    string
+	flagSaveOpts  bool
+	flagSeed      string
+	flagSettings  string
+	flagSpoiler   string
+	flagStart     string
+	flagStats     string
+	flagTreewarp  bool
+	flagTricks    string
+	flagVerbose   bool
 )
 
 // initFlags initializes the CLI/TUI option values and variables.
 func initFlags() {
 	flag.Usage = usage
-	flag.BoolVar(&flagHard, "hard", false,
-		"require some plays outside normal logic")
-	flag.IntVar(&flagN, "n", 100,
-		"number of trials for stats")
-	flag.BoolVar(&flagNoMusic, "nomusic", false,
-		"don't play any music in the modified ROM")
+	registerOptionFlags()
+	flag.StringVar(&flagApply, "apply", "",
+		"apply a BPS or IPS patch to the given vanilla ROM")
+	flag.StringVar(&flagInspect, "inspect", "",
+		"print the item placements in a randomized ROM")
 	flag.BoolVar(&flagNoUI, "noui", false,
 		"use command line output without option prompts")
+	flag.BoolVar(&flagPatchMap, "patchmap", false,
+		"print the bytes each change sets instead of writing a ROM")
+	flag.BoolVar(&flagSaveOpts, "saveopts", false,
+		"write the options used to a JSON preset file next to the log")
 	flag.StringVar(&flagSeed, "seed", "",
 		"specific random seed to use (32-bit hex number)")
 	flag.StringVar(&flagStats, "stats", "",
 		"test routes and print stats for 'seasons' or 'ages'")
-	flag.BoolVar(&flagTreewarp, "treewarp", false,
-		"warp to ember tree by pressing start+B on map screen")
 	flag.BoolVar(&flagVerbose, "verbose", false,
 		"print more detailed output to terminal")
 	flag.Parse()
@@ -80,7 +103,16 @@ func initFlags() {
 func main() {
 	initFlags()
 
-	if flagStats != "" {
+	if flagInspect != "" {
+		// read a randomized ROM instead of randomizing
+		logf := func(s string, a ...interface{}) {
+			fmt.Printf(s, a...)
+			fmt.Println()
+		}
+		if err := inspectROM(flagInspect, logf); err != nil {
+			fmt.Println(err)
+		}
+	} else if flagStats != "" {
 		// do stats instead of randomizing
 		var game int
 
@@ -93,12 +125,28 @@ func main() {
 			return
 		}
 
-		rom.Init(game)
+		if flagPreset != "" {
+			if err := applyPreset(flagPreset); err != nil {
+				fmt.Println(err)
+				return
+			}
+		}
+		if err := checkOptions(); err != nil {
+			fmt.Println(err)
+			return
+		}
+		ro, err := parseRouteFlags(rom.NewContext(game))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
 		rand.Seed(time.Now().UnixNano())
-		logStats(game, flagN, flagHard, func(s string, a ...interface{}) {
-			fmt.Printf(s, a...)
-			fmt.Println()
-		})
+		logStats(game, flagN, ro,
+			func(s string, a ...interface{}) {
+				fmt.Printf(s, a...)
+				fmt.Println()
+			})
 	} else if flag.NArg()+flag.NFlag() > 1 { // CLI used
 		// run randomizer on main goroutine
 		runRandomizer(false, func(s string, a ...interface{}) {
@@ -180,22 +228,44 @@ func runRandomizer(useTUI bool, logf logFunc) {
 		if err != nil {
 			fatal(err, logf)
 			return
-		} else {
-			rom.Init(game)
+		}
+		ctx := rom.NewContext(game)
+
+		if flagApply != "" {
+			if err := applyPatchFile(b, dirName, flagApply, outfile,
+				logf); err != nil {
+				fatal(err, logf)
+			}
+			return
 		}
 		logf("randomizing %s.", infile)
 
-		getAndLogOptions(useTUI, logf)
+		ro, err := getAndLogOptions(ctx, useTUI, logf)
+		if err != nil {
+			fatal(err, logf)
+			return
+		}
 
 		if useTUI {
 			logf("")
 		}
 
-		rom.SetMusic(!flagNoMusic)
-		rom.SetTreewarp(flagTreewarp)
+		// code is placed when the context is made, so the extra banks of an
+		// expanded ROM need a new one.
+		if flagExpand {
+			ctx = rom.NewExpandedContext(game)
+		}
+
+		ctx.SetMusic(!flagNoMusic)
+		ctx.SetTreewarp(flagTreewarp)
+		setDungeonItemModes(ctx, ro.Modes)
+		if err := ctx.SetStartingItems(ro.Start); err != nil {
+			fatal(err, logf)
+			return
+		}
 
-		if err := randomizeFile(b, game, dirName, outfile, flagSeed,
-			flagHard, flagVerbose, logf); err != nil {
+		if err := randomizeFile(ctx, b, dirName, outfile, flagSeed,
+			ro, flagVerbose, logf); err != nil {
 			fatal(err, logf)
 			return
 		}
@@ -203,61 +273,219 @@ func runRandomizer(useTUI bool, logf logFunc) {
 }
 
 // getAndLogOptions logs values of selected options, prompting for them first
-// if the TUI is used.
-func getAndLogOptions(useTUI bool, logf logFunc) {
+// if the TUI is used. it returns the options that affect routing.
+func getAndLogOptions(ctx *rom.Context, useTUI bool,
+	logf logFunc) (routeOptions, error) {
+	game := ctx.Game()
+	if flagSettings != "" {
+		if err := checkSettingsFlags(givenFlags()); err != nil {
+			return routeOptions{}, err
+		}
+	}
+	if flagPreset != "" {
+		if err := applyPreset(flagPreset); err != nil {
+			return routeOptions{}, err
+		}
+		logf("using preset %s.", filepath.Base(flagPreset))
+		useTUI = false
+	}
+	if useTUI && ui.Prompt("use settings string? (y/n)") == 'y' {
+		flagSettings = ui.PromptString("enter settings string:")
+	}
+	if flagSettings != "" {
+		if err := applySettingsString(flagSettings, game); err != nil {
+			return routeOptions{}, err
+		}
+		logf("using settings string.")
+		useTUI = false
+	}
+
 	if useTUI {
 		if ui.Prompt("use specific seed? (y/n)") == 'y' {
 			flagSeed = ui.PromptSeed("enter seed: (8-digit hex number)")
 			logf("using seed %s.", flagSeed)
 		}
+		promptOptions(game)
+	}
+	if err := checkOptions(); err != nil {
+		return routeOptions{}, err
 	}
 
-	if useTUI {
-		flagHard = ui.Prompt("enable hard difficulty? (y/n)") == 'y'
+	ro, err := parseRouteFlags(ctx)
+	if err != nil {
+		return ro, err
+	}
+	logOptions(ctx, logf)
+
+	return ro, nil
+}
+
+// parseTricks returns the set of tricks enabled by the CLI options, or an
+// error if an unknown trick is named. -hard enables every trick for the game.
+func parseTricks(game int) (graph.Tricks, error) {
+	valid := logic.SeasonsTricks()
+	if game == rom.GameAges {
+		valid = logic.AgesTricks()
 	}
+
+	tricks := make(graph.Tricks)
 	if flagHard {
-		logf("using hard difficulty.")
-	} else {
-		logf("using normal difficulty.")
+		for _, name := range valid {
+			tricks[name] = true
+		}
 	}
 
-	if useTUI {
-		flagNoMusic = ui.Prompt("disable music? (y/n)") == 'y'
+	if flagTricks != "" {
+	TrickLoop:
+		for _, name := range strings.Split(flagTricks, ",") {
+			name = strings.TrimSpace(name)
+			for _, validName := range valid {
+				if name == validName {
+					tricks[name] = true
+					continue TrickLoop
+				}
+			}
+			return nil, fmt.Errorf("unknown trick %q; tricks are: %s",
+				name, strings.Join(valid, ", "))
+		}
 	}
-	if flagNoMusic {
-		logf("music off.")
-	} else {
-		logf("music on.")
+
+	return tricks, nil
+}
+
+// splitItemNames splits a comma-separated list of item names, some of which
+// contain commas themselves (e.g. "rupees, 100").
+func splitItemNames(ctx *rom.Context, s string) []string {
+	var names []string
+	for _, part := range strings.Split(s, ",") {
+		if n := len(names); n > 0 && ctx.Treasures[names[n-1]] == nil {
+			names[n-1] += "," + part
+		} else {
+			names = append(names, strings.TrimSpace(part))
+		}
 	}
+	return names
+}
 
-	if useTUI {
-		flagTreewarp = ui.Prompt("enable tree warp? (y/n)") == 'y'
+// trickString returns a sorted, comma-separated list of the enabled tricks.
+func trickString(tricks graph.Tricks) string {
+	if len(tricks) == 0 {
+		return "none"
+	}
+
+	names := make([]string, 0, len(tricks))
+	for name := range tricks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// parseRouteFlags returns the routing options given by the CLI options, or an
+// error if they're invalid for the context's game.
+func parseRouteFlags(ctx *rom.Context) (routeOptions, error) {
+	game := ctx.Game()
+	tricks, err := parseTricks(game)
+	if err != nil {
+		return routeOptions{}, err
+	}
+	ro := routeOptions{
+		Tricks: tricks,
+		Rings:  flagRings,
+	}
+	if flagStart != "" {
+		ro.Start = splitItemNames(ctx, flagStart)
+		if err := ctx.CheckStartingItems(ro.Start); err != nil {
+			return ro, err
+		}
+	}
+	if ro.Plan, err = currentPlan(); err != nil {
+		return ro, err
+	}
+	if ro.Plan != nil {
+		if err := ro.Plan.check(game); err != nil {
+			return ro, err
+		}
+	}
+
+	modes := &ro.Modes
+	if game == rom.GameSeasons {
+		if modes.SmallKeys, err = parseKeyMode(flagD0Key); err != nil {
+			return ro, err
+		}
 	}
-	if flagTreewarp {
-		logf("tree warp on.")
-	} else {
-		logf("tree warp off.")
+	if modes.BossKeys, err = parseItemMode(flagBossKeys); err != nil {
+		return ro, err
 	}
+	if modes.Maps, err = parseItemMode(flagMaps); err != nil {
+		return ro, err
+	}
+	if modes.Compasses, err = parseItemMode(flagCompasses); err != nil {
+		return ro, err
+	}
+	return ro, modes.check(game)
+}
+
+// setDungeonItemModes makes the ROM changes needed for the given modes.
+func setDungeonItemModes(ctx *rom.Context, modes dungeonItemModes) {
+	ctx.SetD0KeyShuffle(modes.SmallKeys != placeVanilla)
+	ctx.SetBossKeysAnywhere(modes.BossKeys == placeAnywhere)
+	ctx.SetStartingBossKeys(modes.BossKeys == placeRemoved)
 }
 
 // attempt to write rom data to a file and print summary info.
-func writeROM(b []byte, dirName, filename, logFilename string, seed uint32,
-	sum []byte, logf logFunc) error {
-	// write file
-	f, err := os.Create(filepath.Join(dirName, filename))
+func writeROM(ctx *rom.Context, vanilla, b []byte, dirName, filename string,
+	logFilenames []string, ri *RouteInfo, sum []byte, settings string,
+	logf logFunc) error {
+	formats, err := parseOutputFormats(flagOutput)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-	if _, err := f.Write(b); err != nil {
-		return err
+
+	// write files
+	written := make([]string, 0, len(formats))
+	for _, format := range formats {
+		name, data := filename, b
+		switch format {
+		case outputBPS:
+			name = replaceExt(filename, ".bps")
+			data = patch.CreateBPS(vanilla, b)
+		case outputIPS:
+			name = replaceExt(filename, ".ips")
+			if data, err = patch.CreateIPS(vanilla, b); err != nil {
+				return err
+			}
+		}
+		if err := ioutil.WriteFile(
+			filepath.Join(dirName, name), data, 0644); err != nil {
+			return err
+		}
+		written = append(written, fmt.Sprintf("wrote new %s to %s",
+			outputNames[format], name))
+
+		// label the randomizer's changes for debugging in an emulator
+		if format == outputROM {
+			symName := replaceExt(filename, ".sym")
+			if err := ioutil.WriteFile(filepath.Join(dirName, symName),
+				[]byte(ctx.Symbols()), 0644); err != nil {
+				return err
+			}
+			written = append(written,
+				fmt.Sprintf("wrote symbol file to %s", symName))
+		}
 	}
 
 	// print summary
-	logf("seed: %08x", seed)
+	logf("seed: %08x", ri.Seed)
 	logf("SHA-1 sum: %x", string(sum))
-	logf("wrote new ROM to %s", filename)
-	logf("wrote log file to %s", logFilename)
+	logf("hash: %s", strings.Join(ri.SeedHash, " / "))
+	logf("settings: %s", settings)
+	for _, line := range written {
+		logf("%s", line)
+	}
+	for _, logFilename := range logFilenames {
+		logf("wrote log file to %s", logFilename)
+	}
 
 	return nil
 }
@@ -303,7 +531,7 @@ func findVanillaROMs() (dirName, seasons, ages string, err error) {
 		}
 
 		// check file data
-		if rom.IsUS(b) && rom.IsVanilla(b) {
+		if rom.IsVanilla(b) {
 			if rom.IsAges(b) {
 				ages = info.Name()
 			} else {
@@ -339,11 +567,14 @@ func readGivenROM(filename string) ([]byte, int, error) {
 		return nil, rom.GameNil,
 			fmt.Errorf("%s is not an oracles ROM", filename)
 	}
-	if !rom.IsUS(b) {
-		return nil, rom.GameNil,
-			fmt.Errorf("%s is a JP ROM; only US is supported", filename)
+	if err := rom.CheckRegion(rom.GetRegion(b)); err != nil {
+		return nil, rom.GameNil, fmt.Errorf("%s: %v", filename, err)
 	}
 	if !rom.IsVanilla(b) {
+		if v := rom.StampedVersion(b); v != "" {
+			return nil, rom.GameNil, fmt.Errorf(
+				"%s was already randomized by version %s", filename, v)
+		}
 		return nil, rom.GameNil,
 			fmt.Errorf("%s is an unrecognized oracles ROM", filename)
 	}
@@ -355,33 +586,40 @@ func readGivenROM(filename string) ([]byte, int, error) {
 	return b, game, nil
 }
 
-func randomizeFile(romData []byte, game int, dirName, outfile, seedFlag string,
-	hard, verbose bool, logf logFunc) error {
-	var seed uint32
-	var sum []byte
-	var err error
+func randomizeFile(ctx *rom.Context, romData []byte, dirName, outfile,
+	seedFlag string, ro routeOptions, verbose bool, logf logFunc) error {
 	var logFilename string
+	game := ctx.Game()
+
+	// keep the vanilla data for patches
+	vanilla := make([]byte, len(romData))
+	copy(vanilla, romData)
 
 	// operate on rom data
 	if outfile != "" {
 		logFilename = outfile[:len(outfile)-4] + "_log.txt"
 	}
-	seed, sum, logFilename, err = randomize(
-		romData, game, dirName, logFilename, seedFlag, hard, verbose, logf)
+	ri, romData, sum, logFilenames, err := randomize(ctx, romData, dirName,
+		logFilename, seedFlag, ro, verbose, logf)
 	if err != nil {
 		return err
 	}
 	hardString := ""
-	if hard {
+	if len(ro.Tricks) > 0 {
 		hardString = "_hard"
 	}
+	if flagPatchMap {
+		logPatchMap(ctx, vanilla, romData, logf)
+		return nil
+	}
 	if outfile == "" {
 		outfile = fmt.Sprintf("%srando_%s_%08x%s.gbc",
-			gameName(game), version, seed, hardString)
+			gameName(game), version, ri.Seed, hardString)
 	}
 
 	// write to file
-	return writeROM(romData, dirName, outfile, logFilename, seed, sum, logf)
+	return writeROM(ctx, vanilla, romData, dirName, outfile, logFilenames, ri,
+		sum, getSettingsString(game, ri.Seed), logf)
 }
 
 // setRandomSeed sets a 32-bit unsigned random seed based on a hexstring, if
@@ -401,124 +639,160 @@ func setRandomSeed(hexString string) (uint32, error) {
 	return seed, nil
 }
 
-// messes up rom data and writes it to a file.
-func randomize(romData []byte, game int, dirName, logFilename, seedFlag string,
-	hard, verbose bool, logf logFunc) (uint32, []byte, string, error) {
+// messes up rom data and writes the logs, returning the new rom data.
+func randomize(ctx *rom.Context, romData []byte, dirName, logFilename,
+	seedFlag string, ro routeOptions, verbose bool,
+	logf logFunc) (*RouteInfo, []byte, []byte, []string, error) {
+	game := ctx.Game()
+
 	// sanity check beforehand
-	if errs := rom.Verify(romData, game); errs != nil {
+	if errs := ctx.Verify(romData); errs != nil {
 		if verbose {
 			for _, err := range errs {
 				logf(err.Error())
 			}
 		}
-		return 0, nil, "", errs[0]
+		return nil, nil, nil, nil, errs[0]
 	}
 
 	seed, err := setRandomSeed(seedFlag)
 	if err != nil {
-		return 0, nil, "", err
+		return nil, nil, nil, nil, err
 	}
 
 	// search for route
-	ri := findRoute(game, seed, hard, verbose, logf)
+	ri := findRoute(ctx, seed, ro, verbose, logf)
 	if ri == nil {
-		return 0, nil, "", fmt.Errorf("no route found")
+		return nil, nil, nil, nil, fmt.Errorf("no route found")
+	}
+	if flagHints {
+		ri.Hints = generateHints(ri, game, ro.Tricks)
 	}
 
-	checksum, err := setROMData(romData, game, ri, logf, verbose)
+	romData, checksum, err := setROMData(ctx, romData, ri, logf, verbose)
 	if err != nil {
-		return 0, nil, "", err
+		return nil, nil, nil, nil, err
+	}
+	ri.SeedHash = getSeedHash(ctx, checksum,
+		getSettingsString(game, ri.Seed))
+
+	// a patch map is only printed, so don't write any files for it
+	if flagPatchMap {
+		return ri, romData, checksum, nil, nil
 	}
 
 	hardString := ""
-	if hard {
+	if len(ro.Tricks) > 0 {
 		hardString = "hard_"
 	}
 	if logFilename == "" {
 		logFilename = fmt.Sprintf("%srando_%s_%08x_%slog.txt",
 			gameName(game), version, ri.Seed, hardString)
 	}
-	summary, summaryDone := getSummaryChannel(
-		filepath.Join(dirName, logFilename))
-
-	// write info to summary file
-	summary <- fmt.Sprintf("seed: %08x", ri.Seed)
-	summary <- fmt.Sprintf("sha-1 sum: %x", checksum)
-	if hard {
-		summary <- fmt.Sprintf("difficulty: hard")
-	} else {
-		summary <- fmt.Sprintf("difficulty: normal")
-	}
-	summary <- ""
-	summary <- ""
 	checks := getChecks(ri)
-	spheres := getSpheres(ri.Route.Graph, checks, hard)
-	summary <- "-- progression items --"
-	summary <- ""
-	logSpheres(summary, checks, spheres,
-		func(name string) bool { return !itemIsJunk(name) })
-	summary <- ""
-	summary <- "-- other items --"
-	summary <- ""
-	logSpheres(summary, checks, spheres, itemIsJunk)
-	if game == rom.GameSeasons {
-		summary <- ""
-		summary <- "default seasons:"
-		summary <- ""
-		for name, area := range rom.Seasons {
-			summary <- fmt.Sprintf("%-15s <- %s",
-				name[:len(name)-7], seasonsByID[int(area.New[0])])
-		}
-		summary <- ""
-		summary <- fmt.Sprintf("natzu region <- %s", []string{
-			"", "natzu prairie", "natzu river", "natzu wasteland",
-		}[ri.Companion])
-	} else {
-		summary <- ""
-		summary <- fmt.Sprintf("animal companion <- %s", []string{
-			"", "ricky", "dimitri", "moosh",
-		}[ri.Companion])
-	}
-
-	close(summary)
-	<-summaryDone
-
-	return ri.Seed, checksum, logFilename, nil
+	spheres := getSpheres(ri.Route.Graph, checks, ro.Tricks)
+
+	logFilenames := make([]string, 0, 2)
+	if flagSpoiler != spoilerJSON {
+		writeTextSpoiler(filepath.Join(dirName, logFilename), game, ri, ro,
+			checksum, checks, spheres)
+		logFilenames = append(logFilenames, logFilename)
+	}
+	if flagSpoiler != spoilerText {
+		jsonFilename := replaceExt(logFilename, ".json")
+		if err := writeJSONSpoiler(filepath.Join(dirName, jsonFilename), game,
+			ri, ro, checksum, checks, spheres); err != nil {
+			return nil, nil, nil, nil, err
+		}
+		logFilenames = append(logFilenames, jsonFilename)
+	}
+	if flagSaveOpts {
+		presetFilename := strings.TrimSuffix(logFilename, "log.txt") +
+			"preset.json"
+		if err := writePreset(filepath.Join(dirName, presetFilename),
+			ctx); err != nil {
+			return nil, nil, nil, nil, err
+		}
+		logFilenames = append(logFilenames, presetFilename)
+	}
+
+	return ri, romData, checksum, logFilenames, nil
 }
 
 // itemIsJunk returns true iff the item with the given name can never be
 // progression, regardless of context.
-func itemIsJunk(name string) bool {
-	switch rom.Treasures[name].ID() {
-	// heart refill, PoH, HC, ring, compass, dungeon map, gasha seed
-	case 0x29, 0x2a, 0x2b, 0x2d, 0x32, 0x33, 0x34:
+func itemIsJunk(ctx *rom.Context, name string) bool {
+	switch ctx.Treasures[name].ID() {
+	// heart refill, PoH, HC, compass, dungeon map, gasha seed
+	case 0x29, 0x2a, 0x2b, 0x32, 0x33, 0x34:
 		return true
+	case 0x2d: // rings that can punch are used by a trick
+		return name != "fist ring" && name != "expert's ring"
 	}
 	return false
 }
 
-// setROMData mutates the ROM data in-place based on the given route.
-func setROMData(romData []byte, game int, ri *RouteInfo, logf logFunc,
-	verbose bool) ([]byte, error) {
+// setROMData mutates the ROM data based on the given route, returning the new
+// data and its checksum.
+func setROMData(ctx *rom.Context, romData []byte, ri *RouteInfo,
+	logf logFunc, verbose bool) ([]byte, []byte, error) {
 	// place selected treasures in slots
+	if ri.Rings != nil {
+		if err := ctx.SetRings(ri.Rings); err != nil {
+			return nil, nil, err
+		}
+	}
 	checks := getChecks(ri)
 	for slot, item := range checks {
 		if verbose {
 			logf("%s <- %s", slot.Name, item.Name)
 		}
-		rom.ItemSlots[slot.Name].Treasure = rom.Treasures[item.Name]
+		ctx.ItemSlots[slot.Name].Treasure = ctx.Treasures[item.Name]
 	}
 
 	// set season data
-	if game == rom.GameSeasons {
+	if ctx.Game() == rom.GameSeasons {
 		for area, id := range ri.Seasons {
-			rom.Seasons[fmt.Sprintf("%s season", area)].New = []byte{id}
+			ctx.Seasons[fmt.Sprintf("%s season", area)].New = []byte{id}
 		}
 	}
 
-	rom.SetAnimal(ri.Companion)
-	rom.SetTunicColor(ri.TunicColor)
+	ctx.SetAnimal(ri.Companion)
+	ctx.SetTunicColor(ri.TunicColor)
 
 	// do it! (but don't write anything)
-	return rom.Mutate(romData, game)
+	romData, checksum, err := ctx.Mutate(romData, version)
+	if err == nil && verbose {
+		logSpaceUsage(ctx, logf)
+	}
+	return romData, checksum, err
+}
+
+// logs each range of bytes changed in the ROM data, by the name of the change
+// that owns it. changed bytes that nothing owns are flagged.
+func logPatchMap(ctx *rom.Context, vanilla, b []byte, logf logFunc) {
+	ranges := ctx.PatchMap(vanilla, b)
+	unclaimed := 0
+	for _, pr := range ranges {
+		owner := pr.Owner
+		if owner == "" {
+			owner = "UNCLAIMED"
+			unclaimed++
+		}
+		logf("%s %s", pr.Location(), owner)
+		logf("  old: % x", pr.Old)
+		logf("  new: % x", pr.New)
+	}
+	if unclaimed > 0 {
+		logf("%d changed ranges aren't owned by any change", unclaimed)
+	}
+}
+
+// logs the free space used and left in each bank.
+func logSpaceUsage(ctx *rom.Context, logf logFunc) {
+	usage, _ := ctx.SpaceUsage()
+	logf("bank   used   free")
+	for _, bu := range usage {
+		logf("  %02x  %5d  %5d", bu.Bank, bu.Used, bu.Free)
+	}
 }
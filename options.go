@@ -0,0 +1,412 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jangler/oracles-randomizer/rom"
+	"github.com/jangler/oracles-randomizer/ui"
+)
+
+// kinds of option values
+const (
+	optionBool = iota
+	optionEnum
+	optionInt
+	optionList   // comma-separated strings
+	optionString // any string
+	optionFile   // the name of a file, which is saved by its contents
+)
+
+// an option is a setting that's registered as a CLI flag, prompted for in the
+// TUI, logged, and saved in settings strings and presets.
+type option struct {
+	name   string // CLI flag name
+	kind   int
+	def    string // default value, in the same form as set() takes
+	desc   string // CLI usage text
+	prompt string // TUI prompt, or empty if the TUI doesn't prompt for it
+	label  string // log label, or empty if it isn't logged
+	game   int    // the only game it applies to, or rom.GameNil for both
+
+	// enum values are saved in settings strings by index, so new values have
+	// to go at the end. gameValues restricts the values for a game in the TUI.
+	// list values, if given, are the only values allowed in a list.
+	values     []string
+	gameValues map[int][]string
+
+	// inclusive range of int values
+	min, max int
+
+	// split splits a list value, if the values can contain commas themselves.
+	split func(ctx *rom.Context, s string) []string
+
+	// file options are saved by their contents instead of the file name,
+	// since the file may not be there when the settings are loaded. contents
+	// returns the contents, or nil if there are none, and setContents sets
+	// the option to them instead of a file.
+	contents    func() ([]byte, error)
+	setContents func(b []byte) error
+
+	// options that don't affect the ROM aren't in settings strings, and meta
+	// options (which choose where other options come from) aren't in presets
+	// either.
+	unsaved bool
+	meta    bool
+
+	boolVar *bool
+	strVar  *string
+	intVar  *int
+}
+
+// options in the order they're prompted for and logged.
+var options = []*option{
+	{
+		name:    "hard",
+		kind:    optionBool,
+		def:     "false",
+		desc:    "enable all tricks (see -tricks)",
+		prompt:  "enable hard difficulty?",
+		label:   "all tricks on",
+		boolVar: &flagHard,
+	},
+	{
+		name:   "tricks",
+		kind:   optionList,
+		desc:   "comma-separated list of tricks to require, e.g. 'bomb jumps'",
+		label:  "tricks",
+		strVar: &flagTricks,
+	},
+	{
+		name:    "nomusic",
+		kind:    optionBool,
+		def:     "false",
+		desc:    "don't play any music in the modified ROM",
+		prompt:  "disable music?",
+		label:   "music off",
+		boolVar: &flagNoMusic,
+	},
+	{
+		name:    "treewarp",
+		kind:    optionBool,
+		def:     "false",
+		desc:    "warp to ember tree by pressing start+B on map screen",
+		prompt:  "enable tree warp?",
+		label:   "tree warp on",
+		boolVar: &flagTreewarp,
+	},
+	{
+		name: "d0key",
+		kind: optionEnum,
+		def:  "off",
+		desc: "shuffle the hero's cave small key within its 'dungeon' or " +
+			"'anywhere' (seasons)",
+		prompt: "shuffle hero's cave key?",
+		label:  "hero's cave key",
+		game:   rom.GameSeasons,
+		values: []string{"off", "dungeon", "anywhere"},
+		strVar: &flagD0Key,
+	},
+	itemModeOption("bosskeys", "boss key", "boss keys", &flagBossKeys),
+	itemModeOption("maps", "map", "maps", &flagMaps),
+	itemModeOption("compasses", "compass", "compasses", &flagCompasses),
+	{
+		name:    "rings",
+		kind:    optionBool,
+		def:     "false",
+		desc:    "shuffle which rings are in chests",
+		prompt:  "shuffle rings?",
+		label:   "rings shuffled",
+		boolVar: &flagRings,
+	},
+	{
+		name:    "hints",
+		kind:    optionBool,
+		def:     "false",
+		desc:    "list hints in the log",
+		label:   "hints on",
+		boolVar: &flagHints,
+	},
+	{
+		name:    "expand",
+		kind:    optionBool,
+		def:     "false",
+		desc:    "write a 2 MiB ROM with extra banks for new code and data",
+		label:   "ROM expanded",
+		boolVar: &flagExpand,
+	},
+	{
+		name:   "start",
+		kind:   optionList,
+		desc:   "comma-separated list of items to start with, e.g. 'feather 1'",
+		label:  "starting items",
+		split:  splitItemNames,
+		strVar: &flagStart,
+	},
+	{
+		name:        "plan",
+		kind:        optionFile,
+		desc:        "JSON or YAML file of fixed item placements",
+		label:       "plan",
+		contents:    planContents,
+		setContents: setPlanContents,
+		strVar:      &flagPlan,
+	},
+	{
+		name:    "spoiler",
+		kind:    optionEnum,
+		def:     spoilerText,
+		desc:    "spoiler log format: 'text', 'json', or 'both'",
+		values:  []string{spoilerText, spoilerJSON, spoilerBoth},
+		unsaved: true,
+		strVar:  &flagSpoiler,
+	},
+	{
+		name:    "output",
+		kind:    optionList,
+		def:     outputROM,
+		desc:    "comma-separated list of outputs: 'rom', 'bps', and/or 'ips'",
+		values:  []string{outputROM, outputBPS, outputIPS},
+		unsaved: true,
+		strVar:  &flagOutput,
+	},
+	{
+		name:    "n",
+		kind:    optionInt,
+		def:     "100",
+		desc:    "number of trials for stats",
+		min:     1,
+		max:     100000,
+		unsaved: true,
+		meta:    true,
+		intVar:  &flagN,
+	},
+	{
+		name:    "preset",
+		kind:    optionString,
+		desc:    "JSON or TOML file of options, which other options override",
+		unsaved: true,
+		meta:    true,
+		strVar:  &flagPreset,
+	},
+	{
+		name:    "settings",
+		kind:    optionString,
+		desc:    "settings string from a log, which sets the seed and all options",
+		unsaved: true,
+		meta:    true,
+		strVar:  &flagSettings,
+	},
+}
+
+// returns an option for the placement of a kind of dungeon item.
+func itemModeOption(name, item, items string, v *string) *option {
+	return &option{
+		name: name,
+		kind: optionEnum,
+		def:  "dungeon",
+		desc: fmt.Sprintf("%s placement: 'vanilla', 'dungeon', "+
+			"'anywhere', or 'removed'", item),
+		prompt: fmt.Sprintf("place %s in:", items),
+		label:  items,
+		values: []string{"vanilla", "dungeon", "anywhere", "removed"},
+		gameValues: map[int][]string{
+			rom.GameAges: {"vanilla", "dungeon", "removed"},
+		},
+		strVar: v,
+	}
+}
+
+// registerOptionFlags adds a CLI flag for each option.
+func registerOptionFlags() {
+	for _, o := range options {
+		switch o.kind {
+		case optionBool:
+			flag.BoolVar(o.boolVar, o.name, o.def == "true", o.desc)
+		case optionInt:
+			def, _ := strconv.Atoi(o.def)
+			flag.IntVar(o.intVar, o.name, def, o.desc)
+		default:
+			flag.StringVar(o.strVar, o.name, o.def, o.desc)
+		}
+	}
+}
+
+// appliesTo returns true if the option has any effect for the game.
+func (o *option) appliesTo(game int) bool {
+	return o.game == rom.GameNil || o.game == game
+}
+
+// allowed returns the values the TUI offers for an enum option.
+func (o *option) allowed(game int) []string {
+	if values, ok := o.gameValues[game]; ok {
+		return values
+	}
+	return o.values
+}
+
+// get returns the option's current value as a string.
+func (o *option) get() string {
+	switch o.kind {
+	case optionBool:
+		return strconv.FormatBool(*o.boolVar)
+	case optionInt:
+		return strconv.Itoa(*o.intVar)
+	}
+	return *o.strVar
+}
+
+// set sets the option's value from a string, or returns an error if the value
+// isn't valid for the option.
+func (o *option) set(s string) error {
+	switch o.kind {
+	case optionBool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for -%s", s, o.name)
+		}
+		*o.boolVar = v
+	case optionEnum:
+		if o.index(s) == -1 {
+			return fmt.Errorf("invalid value %q for -%s; values are: %s",
+				s, o.name, strings.Join(o.values, ", "))
+		}
+		*o.strVar = s
+	case optionInt:
+		v, err := strconv.Atoi(s)
+		if err != nil || v < o.min || v > o.max {
+			return fmt.Errorf("invalid value %q for -%s; must be %d to %d",
+				s, o.name, o.min, o.max)
+		}
+		*o.intVar = v
+	case optionList:
+		if o.values != nil && s != "" {
+			for _, v := range strings.Split(s, ",") {
+				if o.index(strings.TrimSpace(v)) == -1 {
+					return fmt.Errorf("invalid value %q for -%s; values are: %s",
+						v, o.name, strings.Join(o.values, ", "))
+				}
+			}
+		}
+		*o.strVar = s
+	default:
+		*o.strVar = s
+	}
+	return nil
+}
+
+// list returns the values of a list option.
+func (o *option) list(ctx *rom.Context) []string {
+	if *o.strVar == "" {
+		return []string{}
+	}
+	if o.split != nil {
+		return o.split(ctx, *o.strVar)
+	}
+	values := strings.Split(*o.strVar, ",")
+	for i, v := range values {
+		values[i] = strings.TrimSpace(v)
+	}
+	return values
+}
+
+// index returns the index of an enum or list value, or -1 if it isn't one.
+func (o *option) index(s string) int {
+	for i, v := range o.values {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkOptions returns an error if any option has an invalid value, or if
+// the values don't fit in a settings string.
+func checkOptions() error {
+	for _, o := range options {
+		if err := o.set(o.get()); err != nil {
+			return err
+		}
+	}
+	return checkSettingsLengths()
+}
+
+// promptOptions prompts for the value of each option that applies to the
+// game.
+func promptOptions(game int) {
+	for _, o := range options {
+		if o.prompt == "" || !o.appliesTo(game) {
+			continue
+		}
+
+		switch o.kind {
+		case optionBool:
+			*o.boolVar = ui.Prompt(o.prompt+" (y/n)") == 'y'
+		case optionEnum:
+			// values are chosen by their first letter
+			values := o.allowed(game)
+			choices := make([]string, len(values))
+			for i, v := range values {
+				choices[i] = "(" + v[:1] + ")" + v[1:]
+			}
+			choices[len(choices)-1] = "or " + choices[len(choices)-1]
+			c := ui.Prompt(o.prompt + " " + strings.Join(choices, ", "))
+			for _, v := range values {
+				if rune(v[0]) == c {
+					*o.strVar = v
+				}
+			}
+		case optionInt:
+			for {
+				s := ui.PromptString(
+					fmt.Sprintf("%s (%d to %d)", o.prompt, o.min, o.max))
+				if o.set(s) == nil {
+					break
+				}
+			}
+		default:
+			for {
+				if o.set(ui.PromptString(o.prompt)) == nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// logOptions logs the value of each option that applies to the context's
+// game. bool options are only logged if they're enabled, and list, string,
+// and file options are only logged if they're given.
+func logOptions(ctx *rom.Context, logf logFunc) {
+	for _, o := range options {
+		if o.label == "" || !o.appliesTo(ctx.Game()) {
+			continue
+		}
+
+		switch o.kind {
+		case optionBool:
+			if *o.boolVar {
+				logf("%s.", o.label)
+			}
+		case optionEnum, optionInt:
+			logf("%s: %s.", o.label, o.get())
+		case optionList:
+			if values := o.list(ctx); len(values) > 0 {
+				logf("%s: %s.", o.label, strings.Join(values, ", "))
+			}
+		case optionString:
+			if *o.strVar != "" {
+				logf("%s: %s.", o.label, *o.strVar)
+			}
+		case optionFile:
+			if *o.strVar != "" {
+				logf("%s: %s.", o.label, filepath.Base(*o.strVar))
+			} else if b, _ := o.contents(); len(b) > 0 {
+				logf("%s: (inline).", o.label)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/jangler/oracles-randomizer/patch"
+)
+
+// output formats for randomized ROMs
+const (
+	outputROM = "rom"
+	outputBPS = "bps"
+	outputIPS = "ips"
+)
+
+var outputNames = map[string]string{
+	outputROM: "ROM",
+	outputBPS: "BPS patch",
+	outputIPS: "IPS patch",
+}
+
+// parseOutputFormats returns the output formats in a comma-separated list, or
+// an error if any of them are invalid.
+func parseOutputFormats(s string) ([]string, error) {
+	formats := make([]string, 0)
+	for _, format := range strings.Split(s, ",") {
+		format = strings.TrimSpace(format)
+		if outputNames[format] == "" {
+			return nil, fmt.Errorf("invalid output format %q", format)
+		}
+		if !stringInSlice(format, formats) {
+			formats = append(formats, format)
+		}
+	}
+	return formats, nil
+}
+
+// returns the filename with its extension replaced by ext.
+func replaceExt(filename, ext string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + ext
+}
+
+// applies the patch in the named file to vanilla ROM data and writes the
+// result. if outfile is empty, the output is named after the patch.
+func applyPatchFile(romData []byte, dirName, patchFilename, outfile string,
+	logf logFunc) error {
+	patchData, err := ioutil.ReadFile(patchFilename)
+	if err != nil {
+		return err
+	}
+	b, err := patch.Apply(patchData, romData)
+	if err != nil {
+		return err
+	}
+
+	if outfile == "" {
+		outfile = replaceExt(filepath.Base(patchFilename), ".gbc")
+	}
+	if err := ioutil.WriteFile(
+		filepath.Join(dirName, outfile), b, 0644); err != nil {
+		return err
+	}
+
+	logf("wrote patched ROM to %s", outfile)
+	return nil
+}
@@ -0,0 +1,276 @@
+// Package patch creates and applies BPS and IPS patches, so that modified
+// ROMs can be distributed without the original ROM data.
+package patch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// magic numbers at the start of each patch format
+const (
+	bpsMagic = "BPS1"
+	ipsMagic = "PATCH"
+	ipsEOF   = "EOF"
+)
+
+// BPS actions
+const (
+	sourceRead = iota
+	targetRead
+	sourceCopy
+	targetCopy
+)
+
+// CreateBPS returns a BPS patch that turns source into target.
+func CreateBPS(source, target []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString(bpsMagic)
+	writeNumber(&b, uint64(len(source)))
+	writeNumber(&b, uint64(len(target)))
+	writeNumber(&b, 0) // no metadata
+
+	// runs of unchanged bytes are read from the source, and the rest are
+	// included in the patch.
+	for i := 0; i < len(target); {
+		start := i
+		if i < len(source) && source[i] == target[i] {
+			for i < len(target) && i < len(source) && source[i] == target[i] {
+				i++
+			}
+			writeNumber(&b, uint64(i-start-1)<<2|sourceRead)
+		} else {
+			for i < len(target) && (i >= len(source) || source[i] != target[i]) {
+				i++
+			}
+			writeNumber(&b, uint64(i-start-1)<<2|targetRead)
+			b.Write(target[start:i])
+		}
+	}
+
+	writeCRC(&b, crc32.ChecksumIEEE(source))
+	writeCRC(&b, crc32.ChecksumIEEE(target))
+	writeCRC(&b, crc32.ChecksumIEEE(b.Bytes()))
+	return b.Bytes()
+}
+
+// CreateIPS returns an IPS patch that turns source into target. It returns an
+// error if target is shorter than source or too long for IPS offsets.
+func CreateIPS(source, target []byte) ([]byte, error) {
+	if len(target) < len(source) {
+		return nil, fmt.Errorf("IPS patches can't shrink files")
+	}
+	if len(target) > 1<<24 {
+		return nil, fmt.Errorf("file too large for IPS patch")
+	}
+
+	var b bytes.Buffer
+	b.WriteString(ipsMagic)
+	for i := 0; i < len(target); {
+		if i < len(source) && source[i] == target[i] {
+			i++
+			continue
+		}
+
+		// an offset that spells "EOF" would end the patch, so start the
+		// record a byte earlier instead.
+		start := i
+		if start == 0x454f46 {
+			start--
+		}
+		for i < len(target) && i-start < 0xffff &&
+			(i >= len(source) || source[i] != target[i]) {
+			i++
+		}
+
+		b.Write([]byte{byte(start >> 16), byte(start >> 8), byte(start)})
+		b.Write([]byte{byte((i - start) >> 8), byte(i - start)})
+		b.Write(target[start:i])
+	}
+	b.WriteString(ipsEOF)
+
+	return b.Bytes(), nil
+}
+
+// Apply applies a BPS or IPS patch to source and returns the result. It
+// returns an error if the patch is invalid or, for BPS, if the checksums
+// don't match.
+func Apply(patch, source []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(patch, []byte(bpsMagic)):
+		return applyBPS(patch, source)
+	case bytes.HasPrefix(patch, []byte(ipsMagic)):
+		return applyIPS(patch, source)
+	}
+	return nil, fmt.Errorf("unknown patch format")
+}
+
+func applyBPS(patch, source []byte) ([]byte, error) {
+	if len(patch) < len(bpsMagic)+12 {
+		return nil, fmt.Errorf("BPS patch is too short")
+	}
+	footer := patch[len(patch)-12:]
+	if crc32.ChecksumIEEE(patch[:len(patch)-4]) !=
+		binary.LittleEndian.Uint32(footer[8:]) {
+		return nil, fmt.Errorf("BPS patch is corrupt")
+	}
+	if crc32.ChecksumIEEE(source) != binary.LittleEndian.Uint32(footer) {
+		return nil, fmt.Errorf("BPS patch is for a different file")
+	}
+
+	r := &reader{b: patch[:len(patch)-12], i: len(bpsMagic)}
+	sourceSize, targetSize := r.number(), r.number()
+	r.i += int(r.number()) // skip metadata
+	if r.err != nil || sourceSize != uint64(len(source)) {
+		return nil, fmt.Errorf("BPS patch is for a different file")
+	}
+
+	target := make([]byte, 0, targetSize)
+	var sourceOffset, targetOffset int
+	for r.i < len(r.b) && r.err == nil {
+		data := r.number()
+		length := int(data>>2) + 1
+
+		switch data & 3 {
+		case sourceRead:
+			start := len(target)
+			if start+length > len(source) {
+				return nil, fmt.Errorf("BPS patch reads past end of file")
+			}
+			target = append(target, source[start:start+length]...)
+		case targetRead:
+			if r.i+length > len(r.b) {
+				return nil, fmt.Errorf("BPS patch is truncated")
+			}
+			target = append(target, r.b[r.i:r.i+length]...)
+			r.i += length
+		case sourceCopy:
+			sourceOffset += r.offset()
+			if sourceOffset < 0 || sourceOffset+length > len(source) {
+				return nil, fmt.Errorf("BPS patch reads past end of file")
+			}
+			target = append(target,
+				source[sourceOffset:sourceOffset+length]...)
+			sourceOffset += length
+		case targetCopy:
+			targetOffset += r.offset()
+			if targetOffset < 0 || targetOffset >= len(target) {
+				return nil, fmt.Errorf("BPS patch reads past end of file")
+			}
+			// byte by byte, since the copy can overlap what it writes
+			for j := 0; j < length; j++ {
+				target = append(target, target[targetOffset])
+				targetOffset++
+			}
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if uint64(len(target)) != targetSize ||
+		crc32.ChecksumIEEE(target) != binary.LittleEndian.Uint32(footer[4:]) {
+		return nil, fmt.Errorf("BPS patch produced the wrong file")
+	}
+	return target, nil
+}
+
+func applyIPS(patch, source []byte) ([]byte, error) {
+	target := make([]byte, len(source))
+	copy(target, source)
+
+	for i := len(ipsMagic); ; {
+		if i+3 > len(patch) {
+			return nil, fmt.Errorf("IPS patch is truncated")
+		}
+		if string(patch[i:i+3]) == ipsEOF {
+			break
+		}
+		if i+5 > len(patch) {
+			return nil, fmt.Errorf("IPS patch is truncated")
+		}
+		offset := int(patch[i])<<16 | int(patch[i+1])<<8 | int(patch[i+2])
+		size := int(patch[i+3])<<8 | int(patch[i+4])
+		i += 5
+
+		// a size of zero means a run of one repeated byte
+		var data []byte
+		if size == 0 {
+			if i+3 > len(patch) {
+				return nil, fmt.Errorf("IPS patch is truncated")
+			}
+			size = int(patch[i])<<8 | int(patch[i+1])
+			data = bytes.Repeat(patch[i+2:i+3], size)
+			i += 3
+		} else {
+			if i+size > len(patch) {
+				return nil, fmt.Errorf("IPS patch is truncated")
+			}
+			data = patch[i : i+size]
+			i += size
+		}
+
+		for len(target) < offset+size {
+			target = append(target, 0)
+		}
+		copy(target[offset:], data)
+	}
+
+	return target, nil
+}
+
+// writes a number in BPS's variable-length encoding.
+func writeNumber(b *bytes.Buffer, n uint64) {
+	for {
+		x := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			b.WriteByte(0x80 | x)
+			return
+		}
+		b.WriteByte(x)
+		n--
+	}
+}
+
+func writeCRC(b *bytes.Buffer, crc uint32) {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], crc)
+	b.Write(buf[:])
+}
+
+// reads BPS numbers from a patch, recording the first error.
+type reader struct {
+	b   []byte
+	i   int
+	err error
+}
+
+func (r *reader) number() uint64 {
+	var n, shift uint64 = 0, 1
+	for {
+		if r.i >= len(r.b) {
+			r.err = fmt.Errorf("BPS patch is truncated")
+			return 0
+		}
+		x := r.b[r.i]
+		r.i++
+		n += uint64(x&0x7f) * shift
+		if x&0x80 != 0 {
+			return n
+		}
+		shift <<= 7
+		n += shift
+	}
+}
+
+// reads a signed offset for the copy actions.
+func (r *reader) offset() int {
+	data := r.number()
+	if data&1 != 0 {
+		return -int(data >> 1)
+	}
+	return int(data >> 1)
+}
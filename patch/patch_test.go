@@ -0,0 +1,100 @@
+package patch
+
+import (
+	"bytes"
+	"hash/crc32"
+	"math/rand"
+	"testing"
+)
+
+// returns random source data and a copy of it with some bytes changed and
+// some appended.
+func testFiles(size int) (source, target []byte) {
+	src := rand.New(rand.NewSource(0))
+	source = make([]byte, size)
+	src.Read(source)
+	target = make([]byte, size, size+16)
+	copy(target, source)
+	for i := 0; i < 100; i++ {
+		target[src.Intn(size)] ^= 0xff
+	}
+	copy(target[size/2:], bytes.Repeat([]byte{0x12}, 300))
+	return source, append(target, bytes.Repeat([]byte{0x34}, 16)...)
+}
+
+func TestBPS(t *testing.T) {
+	source, target := testFiles(0x100000)
+	patch := CreateBPS(source, target)
+
+	result, err := Apply(patch, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, target) {
+		t.Error("patched file doesn't match target")
+	}
+
+	if _, err := Apply(patch, target); err == nil {
+		t.Error("no error for wrong source file")
+	}
+	patch[len(patch)/2] ^= 0xff
+	if _, err := Apply(patch, source); err == nil {
+		t.Error("no error for corrupt patch")
+	}
+}
+
+func TestBPSCopies(t *testing.T) {
+	source := []byte("abcdef")
+	target := []byte("defxxxxabc")
+
+	// this patch uses the copy actions, which CreateBPS doesn't.
+	var b bytes.Buffer
+	b.WriteString(bpsMagic)
+	writeNumber(&b, uint64(len(source)))
+	writeNumber(&b, uint64(len(target)))
+	writeNumber(&b, 0)
+	writeNumber(&b, 2<<2|sourceCopy) // "def"
+	writeNumber(&b, 3<<1)
+	writeNumber(&b, 0<<2|targetRead) // "x"
+	b.WriteByte('x')
+	writeNumber(&b, 2<<2|targetCopy) // "xxx"
+	writeNumber(&b, 3<<1)
+	writeNumber(&b, 2<<2|sourceCopy) // "abc"
+	writeNumber(&b, 6<<1|1)
+	writeCRC(&b, crc32.ChecksumIEEE(source))
+	writeCRC(&b, crc32.ChecksumIEEE(target))
+	writeCRC(&b, crc32.ChecksumIEEE(b.Bytes()))
+
+	result, err := Apply(b.Bytes(), source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, target) {
+		t.Errorf("want %q, got %q", target, result)
+	}
+}
+
+func TestIPS(t *testing.T) {
+	// large enough to include the offset that looks like "EOF"
+	source, target := testFiles(0x500000)
+	target[0x454f46] ^= 0xff
+
+	patch, err := CreateIPS(source, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Apply(patch, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, target) {
+		t.Error("patched file doesn't match target")
+	}
+
+	if _, err := CreateIPS(target, source); err == nil {
+		t.Error("no error for shrinking file")
+	}
+	if _, err := Apply(patch[:len(patch)-4], source); err == nil {
+		t.Error("no error for truncated patch")
+	}
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jangler/oracles-randomizer/graph"
+	"github.com/jangler/oracles-randomizer/rom"
+)
+
+// A plan is a set of placements chosen by the user instead of the randomizer.
+// Anything the plan doesn't specify is randomized around it as usual.
+type plan struct {
+	Items     map[string]string `json:"items"`     // slot -> item
+	Trees     map[string]string `json:"trees"`     // seed tree -> seed type
+	Seasons   map[string]string `json:"seasons"`   // area -> default season
+	Companion string            `json:"companion"` // ricky, dimitri, or moosh
+}
+
+// a plan given in a settings string or preset instead of a file, if any.
+var inlinePlan *plan
+
+// returns the name of the plan file in use, for logging.
+func planName() string {
+	if flagPlan == "" {
+		return "(inline)"
+	}
+	return filepath.Base(flagPlan)
+}
+
+// returns the plan given by the plan option, from its file or inline, or nil
+// if there is none.
+func currentPlan() (*plan, error) {
+	if flagPlan != "" {
+		return readPlan(flagPlan)
+	}
+	return inlinePlan, nil
+}
+
+// returns the plan given by the plan option as JSON, or nil if there is none.
+func planContents() ([]byte, error) {
+	p, err := currentPlan()
+	if p == nil {
+		return nil, err
+	}
+	return json.Marshal(p)
+}
+
+// sets the plan option to an inline plan parsed from JSON, or to no plan if
+// the JSON is empty.
+func setPlanContents(b []byte) error {
+	flagPlan, inlinePlan = "", nil
+	if len(b) == 0 {
+		return nil
+	}
+	var err error
+	inlinePlan, err = parseJSONPlan(b)
+	return err
+}
+
+// readPlan loads a plan from a JSON or YAML file, depending on the file
+// extension.
+func readPlan(filename string) (*plan, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return parseYAMLPlan(b)
+	default:
+		return parseJSONPlan(b)
+	}
+}
+
+// parseJSONPlan parses a plan from JSON.
+func parseJSONPlan(b []byte) (*plan, error) {
+	p := &plan{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(p); err != nil {
+		return nil, fmt.Errorf("plan: %v", err)
+	}
+	return p, nil
+}
+
+// parseYAMLPlan parses a plan from the small subset of YAML that a plan needs:
+// top-level "key: value" pairs and sections of indented "key: value" pairs.
+// keys and values may be quoted, and lines starting with # are ignored.
+func parseYAMLPlan(b []byte) (*plan, error) {
+	p := &plan{}
+	var section map[string]string
+
+	for i, line := range strings.Split(string(b), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		key, value, err := splitYAMLPair(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("plan: line %d: %v", i+1, err)
+		}
+
+		if line[0] == ' ' || line[0] == '\t' {
+			// indented entry of the current section
+			if section == nil {
+				return nil, fmt.Errorf("plan: line %d: unexpected indent", i+1)
+			}
+			section[key] = value
+			continue
+		}
+
+		section = nil
+		switch key {
+		case "items":
+			p.Items = make(map[string]string)
+			section = p.Items
+		case "trees":
+			p.Trees = make(map[string]string)
+			section = p.Trees
+		case "seasons":
+			p.Seasons = make(map[string]string)
+			section = p.Seasons
+		case "companion":
+			p.Companion = value
+			continue
+		default:
+			return nil, fmt.Errorf("plan: line %d: unknown key %q", i+1, key)
+		}
+		if value != "" {
+			return nil, fmt.Errorf("plan: line %d: %q must be a section",
+				i+1, key)
+		}
+	}
+
+	return p, nil
+}
+
+// splitYAMLPair splits a "key: value" line into its unquoted key and value.
+func splitYAMLPair(line string) (key, value string, err error) {
+	key, rest, err := readYAMLString(line, ':')
+	if err != nil {
+		return "", "", err
+	}
+	if !strings.HasPrefix(rest, ":") {
+		return "", "", fmt.Errorf("missing ':'")
+	}
+	value, rest, err = readYAMLString(strings.TrimSpace(rest[1:]), '#')
+	if err != nil {
+		return "", "", err
+	}
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return "", "", fmt.Errorf("unexpected %q", rest)
+	}
+	return key, value, nil
+}
+
+// readYAMLString reads a quoted string, or an unquoted string up to the given
+// delimiter, from the start of s. it returns the string and the remainder of
+// s.
+func readYAMLString(s string, delim byte) (str, rest string, err error) {
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		end := strings.IndexByte(s[1:], s[0])
+		if end == -1 {
+			return "", "", fmt.Errorf("unterminated string")
+		}
+		return s[1 : end+1], strings.TrimSpace(s[end+2:]), nil
+	}
+
+	end := strings.IndexByte(s, delim)
+	if end == -1 {
+		end = len(s)
+	}
+	return strings.TrimSpace(s[:end]), s[end:], nil
+}
+
+// check returns an error if anything in the plan doesn't exist in the game.
+// placements are checked against the logic later, during routing.
+func (p *plan) check(game int) error {
+	if game != rom.GameSeasons && len(p.Seasons) > 0 {
+		return fmt.Errorf("plan: ages has no default seasons")
+	}
+	for area, season := range p.Seasons {
+		if !stringInSlice(area, seasonAreas) {
+			return fmt.Errorf("plan: unknown season area %q", area)
+		}
+		if !stringInSlice(season, seasonsByID) {
+			return fmt.Errorf("plan: unknown season %q", season)
+		}
+	}
+
+	if p.Companion != "" && p.companionID() == 0 {
+		return fmt.Errorf("plan: unknown animal companion %q", p.Companion)
+	}
+
+	for tree, seed := range p.Trees {
+		if !slotIsSeedTree(tree) {
+			return fmt.Errorf("plan: %q is not a seed tree", tree)
+		}
+		if !stringInSlice(seed, seedNames) {
+			return fmt.Errorf("plan: %q is not a seed type", seed)
+		}
+	}
+	for slot := range p.Items {
+		if _, ok := p.Trees[slot]; ok {
+			return fmt.Errorf("plan: %q is placed twice", slot)
+		}
+	}
+
+	return nil
+}
+
+// companionID returns the ID of the plan's animal companion, or zero if it
+// doesn't specify a valid one.
+func (p *plan) companionID() int {
+	switch p.Companion {
+	case "ricky":
+		return ricky
+	case "dimitri":
+		return dimitri
+	case "moosh":
+		return moosh
+	}
+	return 0
+}
+
+// placements returns the plan's item and seed tree placements combined into a
+// single map of slot names to item names.
+func (p *plan) placements() map[string]string {
+	placements := make(map[string]string, len(p.Items)+len(p.Trees))
+	for slot, item := range p.Items {
+		placements[slot] = item
+	}
+	for tree, seed := range p.Trees {
+		placements[tree] = seed
+	}
+	return placements
+}
+
+// extraSeeds returns a seed type for each time a seed type is planned in more
+// than one seed tree, so that duplicate trees can be given those types.
+func (p *plan) extraSeeds() []string {
+	counts := make(map[string]int)
+	for slot, item := range p.placements() {
+		if slotIsSeedTree(slot) {
+			counts[item]++
+		}
+	}
+
+	var extras []string
+	for _, name := range seedNames {
+		for i := 1; i < counts[name]; i++ {
+			extras = append(extras, name)
+		}
+	}
+	return extras
+}
+
+// place moves the planned items from the item pool into their slots, or
+// returns an error if a placement isn't possible.
+func (p *plan) place(r *Route, itemList, usedItems, slotList,
+	usedSlots *list.List) error {
+	placements := p.placements()
+	slotNames := make([]string, 0, len(placements))
+	for slotName := range placements {
+		slotNames = append(slotNames, slotName)
+	}
+	sort.Strings(slotNames)
+
+	for _, slotName := range slotNames {
+		itemName := placements[slotName]
+
+		es := findNodeInList(slotName, slotList)
+		if es == nil {
+			return fmt.Errorf("%q is not an open item slot", slotName)
+		}
+		ei := findNodeInList(itemName, itemList)
+		if ei == nil {
+			return fmt.Errorf("no %s left to place in %s", itemName, slotName)
+		}
+		slot, item := es.Value.(*graph.Node), ei.Value.(*graph.Node)
+
+		if romSlot := r.ROM.ItemSlots[slotName]; romSlot != nil {
+			vanillaName := r.ROM.FindTreasureName(romSlot.Treasure)
+			if r.Modes.itemMode(vanillaName) == placeVanilla {
+				return fmt.Errorf("%s is reserved for its vanilla %s",
+					slotName, vanillaName)
+			}
+		}
+
+		if err := checkPlannedDungeonItem(r, item, slot); err != nil {
+			return err
+		}
+		if !itemFitsInSlot(r, item, slot, nil) {
+			return fmt.Errorf("%s can't be placed in %s", itemName, slotName)
+		}
+
+		item.AddParents(slot)
+		usedSlots.PushBack(slot)
+		slotList.Remove(es)
+		usedItems.PushBack(item)
+		itemList.Remove(ei)
+	}
+
+	return nil
+}
+
+// checkPlannedDungeonItem returns an error if the item is a dungeon item whose
+// placement mode doesn't allow it to be placed in the slot.
+func checkPlannedDungeonItem(r *Route, item, slot *graph.Node) error {
+	switch r.Modes.itemMode(item.Name) {
+	case placeOwnDungeon:
+		if item.Name == "dungeon map" || item.Name == "compass" {
+			return fmt.Errorf("%s placement is set by dungeon item options",
+				item.Name)
+		}
+		if dungeonIndex(item) != dungeonIndex(slot) {
+			return fmt.Errorf("%s must be placed in its own dungeon, not %s",
+				item.Name, slot.Name)
+		}
+	case placeVanilla, placeRemoved:
+		return fmt.Errorf("%s placement is set by dungeon item options",
+			item.Name)
+	}
+	return nil
+}
+
+// unreachable returns a reason for each planned slot that can't be reached
+// even if every unplaced item is available, since those slots would make the
+// seed unbeatable. it returns nil if the plan is fine.
+func (p *plan) unreachable(r *Route, itemList *list.List,
+	tricks graph.Tricks) []string {
+	// give all the unplaced items at the start, then see what's reachable
+	start := r.Graph["start"]
+	given := make(map[*graph.Node]bool)
+	for ei := itemList.Front(); ei != nil; ei = ei.Next() {
+		item := ei.Value.(*graph.Node)
+		if !given[item] && !graph.IsNodeInSlice(start, item.Parents()) {
+			item.AddParents(start)
+			given[item] = true
+		}
+	}
+	defer func() {
+		for item := range given {
+			item.RemoveParent(start)
+		}
+		r.Graph.ClearMarks()
+	}()
+
+	reached := r.Graph.ExploreFromStart(tricks)
+
+	var reasons []string
+	placements := p.placements()
+	for slotName, itemName := range placements {
+		if !reached[r.Graph[slotName]] {
+			reasons = append(reasons, fmt.Sprintf(
+				"%s in %s can't be reached, which makes the seed unbeatable",
+				itemName, slotName))
+		}
+	}
+	if len(reasons) == 0 && !reached[r.Graph["done"]] {
+		reasons = append(reasons, "the seed can't be beaten with this plan")
+	}
+	sort.Strings(reasons)
+
+	return reasons
+}
+
+// returns the first element of the list whose node has the given name, or nil
+// if there is none.
+func findNodeInList(name string, l *list.List) *list.Element {
+	for e := l.Front(); e != nil; e = e.Next() {
+		if e.Value.(*graph.Node).Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// returns true iff the string is in the slice.
+func stringInSlice(s string, slice []string) bool {
+	for _, match := range slice {
+		if s == match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jangler/oracles-randomizer/rom"
+)
+
+// A preset is a set of generation options loaded from a JSON or TOML file.
+// keys are the names of CLI options, except for meta options. list options
+// are lists, and file options are file names relative to the preset, or in
+// JSON the file contents inline. options the preset doesn't give keep their
+// defaults.
+type preset map[string]interface{}
+
+// readPreset loads a preset from a JSON or TOML file, depending on the file
+// extension.
+func readPreset(filename string) (preset, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.ToLower(filepath.Ext(filename)) == ".toml" {
+		return parseTOMLPreset(b)
+	}
+	p := make(preset)
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("preset: %v", err)
+	}
+	return p, nil
+}
+
+// parseTOMLPreset parses a preset from the subset of TOML that a preset needs:
+// "key = value" lines, where values are strings, booleans, integers, or
+// single-line arrays of strings. lines starting with # are ignored.
+func parseTOMLPreset(b []byte) (preset, error) {
+	p := make(preset)
+
+	for i, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		eq := strings.IndexByte(line, '=')
+		if eq == -1 {
+			return nil, fmt.Errorf("preset: line %d: missing '='", i+1)
+		}
+		key := strings.Trim(strings.TrimSpace(line[:eq]), `"`)
+		value, err := parseTOMLValue(strings.TrimSpace(line[eq+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("preset: line %d: %v", i+1, err)
+		}
+		p[key] = value
+	}
+
+	return p, nil
+}
+
+// parseTOMLValue parses the value part of a TOML "key = value" line.
+func parseTOMLValue(s string) (interface{}, error) {
+	if strings.HasPrefix(s, "[") {
+		list := make([]interface{}, 0)
+		s = strings.TrimSpace(s[1:])
+		for !strings.HasPrefix(s, "]") {
+			if s == "" || (s[0] != '"' && s[0] != '\'') {
+				return nil, fmt.Errorf("array values must be quoted strings")
+			}
+			str, rest, err := readYAMLString(s, ',')
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, str)
+			s = strings.TrimSpace(strings.TrimPrefix(rest, ","))
+		}
+		return list, checkTOMLComment(s[1:])
+	}
+
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		str, rest, err := readYAMLString(s, '#')
+		if err != nil {
+			return nil, err
+		}
+		return str, checkTOMLComment(rest)
+	}
+
+	if i := strings.IndexByte(s, '#'); i != -1 {
+		s = strings.TrimSpace(s[:i])
+	}
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, nil
+	}
+	return nil, fmt.Errorf("invalid value %q", s)
+}
+
+// returns an error if s is anything but whitespace or a comment.
+func checkTOMLComment(s string) error {
+	s = strings.TrimSpace(s)
+	if s != "" && !strings.HasPrefix(s, "#") {
+		return fmt.Errorf("unexpected %q", s)
+	}
+	return nil
+}
+
+// applyPreset sets the CLI option values to the ones in a preset file, except
+// for options that were given on the command line.
+func applyPreset(filename string) error {
+	p, err := readPreset(filename)
+	if err != nil {
+		return err
+	}
+
+	given := givenFlags()
+	for key, value := range p {
+		if given[key] {
+			continue
+		}
+		if err := p.apply(key, value, filepath.Dir(filename)); err != nil {
+			return fmt.Errorf("preset: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// givenFlags returns the set of names of flags given on the command line.
+func givenFlags() map[string]bool {
+	given := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		given[f.Name] = true
+	})
+	return given
+}
+
+// sets the CLI option value for one key of a preset.
+func (p preset) apply(key string, value interface{}, dir string) error {
+	var o *option
+	for _, o2 := range options {
+		if o2.name == key && !o2.meta {
+			o = o2
+		}
+	}
+	if o == nil {
+		return fmt.Errorf("unknown option %q", key)
+	}
+
+	switch o.kind {
+	case optionList:
+		list, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%q must be a list", key)
+		}
+		values := make([]string, len(list))
+		for i, v := range list {
+			values[i] = fmt.Sprint(v)
+		}
+		return o.set(strings.Join(values, ","))
+	case optionFile:
+		if filename, ok := value.(string); ok {
+			if !filepath.IsAbs(filename) {
+				filename = filepath.Join(dir, filename)
+			}
+			return o.set(filename)
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		return o.setContents(b)
+	}
+	return o.set(fmt.Sprint(value))
+}
+
+// writePreset writes the generation options for the context's game to a JSON
+// preset file, which gives the same options when loaded. file options are
+// written inline.
+func writePreset(filename string, ctx *rom.Context) error {
+	p := make(preset)
+	for _, o := range options {
+		if o.meta || !o.appliesTo(ctx.Game()) {
+			continue
+		}
+		switch o.kind {
+		case optionBool:
+			p[o.name] = *o.boolVar
+		case optionInt:
+			p[o.name] = *o.intVar
+		case optionList:
+			p[o.name] = o.list(ctx)
+		case optionFile:
+			contents, err := o.contents()
+			if err != nil {
+				return err
+			}
+			if contents != nil {
+				p[o.name] = json.RawMessage(contents)
+			}
+		default:
+			p[o.name] = *o.strVar
+		}
+	}
+
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, append(b, '\n'), 0644)
+}
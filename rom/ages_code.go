@@ -1,92 +1,168 @@
 package rom
 
 import (
+	"fmt"
 	"strings"
 )
 
+// free space at the end of each bank, where code and data can be added.
 func newAgesRomBanks() *romBanks {
-	r := romBanks{
-		endOfBank: make([]uint16, 0x40),
-	}
-
-	r.endOfBank[0x00] = 0x3ef8
-	r.endOfBank[0x01] = 0x7fc3
-	r.endOfBank[0x02] = 0x7e93
-	r.endOfBank[0x03] = 0x7ebd
-	r.endOfBank[0x04] = 0x7edb
-	r.endOfBank[0x05] = 0x7d9d
-	r.endOfBank[0x06] = 0x7a31
-	r.endOfBank[0x09] = 0x7dee
-	r.endOfBank[0x0a] = 0x7e09
-	r.endOfBank[0x0b] = 0x7fa8
-	r.endOfBank[0x0c] = 0x7f94
-	r.endOfBank[0x0f] = 0x7f90
-	r.endOfBank[0x10] = 0x7ef4
-	r.endOfBank[0x11] = 0x7f73
-	r.endOfBank[0x12] = 0x7e8f
-	r.endOfBank[0x15] = 0x7bfb
-	r.endOfBank[0x16] = 0x7e03
-	r.endOfBank[0x3f] = 0x7d0a
-
-	return &r
+	return newRomBanks([]freeRegion{
+		{0x00, 0x3ef8, 0x4000},
+		{0x01, 0x7fc3, 0x8000},
+		{0x02, 0x7e93, 0x8000},
+		{0x03, 0x7ebd, 0x8000},
+		{0x04, 0x7edb, 0x8000},
+		{0x05, 0x7d9d, 0x8000},
+		{0x06, 0x7a31, 0x8000},
+		{0x09, 0x7dee, 0x8000},
+		{0x0a, 0x7e09, 0x8000},
+		{0x0b, 0x7fa8, 0x8000},
+		{0x0c, 0x7f94, 0x8000},
+		{0x0f, 0x7f90, 0x8000},
+		{0x10, 0x7ef4, 0x8000},
+		{0x11, 0x7f73, 0x8000},
+		{0x12, 0x7e8f, 0x8000},
+		{0x15, 0x7bfb, 0x8000},
+		{0x16, 0x7e03, 0x8000},
+		{0x3f, 0x7d0a, stampOffset},
+	})
 }
 
-func initAgesEOB() {
+func (ctx *Context) initAgesEOB() {
 	r := newAgesRomBanks()
+	if ctx.expanded {
+		r.addExpandedBanks()
+	}
+	ctx.banks = r
 
 	// bank 00
 
 	// don't play any music if the -nomusic flag is given.
-	noMusicFunc := r.appendToBank(0x00, "no music func",
-		"\x67\xfe\x47\x30\x03\x3e\x08\xc9\xf0\xb7\xc9")
-	r.replace(0x00, 0x0c9a, "no music call",
-		"\x67\xf0\xb7", "\xcd"+noMusicFunc)
+	r.appendASM(0x00, "no music func", `
+		ld h,a
+		cp a,$47
+		jr nc,play
+		ld a,$08
+		ret
+	play:
+		ldh a,($b7)
+		ret`)
+	r.replaceASM(0x00, 0x0c9a, "no music call",
+		"\x67\xf0\xb7", "call no_music_func")
 
 	// only increment the maku tree's state if on the maku tree screen, or if
 	// all essences are obtained, set it to the value it would normally have at
 	// that point in the game. this allows getting the maku tree's item as long
 	// as you haven't collected all essences.
-	makuStateCheck := r.appendToBank(0x00, "maku state check",
-		"\xfa\x2d\xcc\xfe\x02\x30\x0e\xfa\x30\xcc\xfe\x38\x20\x07"+
-			"\xfa\xe8\xc6\x3c\xfe\x11\xc9\xfa\xbf\xc6\x3c\x37\x20\x03"+
-			"\x3e\x0e\xc9\xfa\xe8\xc6\xc9")
-	r.replace(0x00, 0x3e56, "call maku state check",
-		"\x3c\xfe\x11", "\xcd"+makuStateCheck)
+	r.appendASM(0x00, "maku state check", `
+		ld a,($cc2d)
+		cp a,$02
+		jr nc,next
+		ld a,($cc30)
+		cp a,$38
+		jr nz,next
+		ld a,($c6e8)
+		inc a
+		cp a,$11
+		ret
+	next:
+		ld a,($c6bf)
+		inc a
+		scf
+		jr nz,next2
+		ld a,$0e
+		ret
+	next2:
+		ld a,($c6e8)
+		ret`)
+	r.replaceASM(0x00, 0x3e56, "call maku state check",
+		"\x3c\xfe\x11", "call maku_state_check")
 
 	// return z iff the current group and room match c and b.
-	compareRoom := r.appendToBank(0x00, "compare room",
-		"\xfa\x2d\xcc\xb9\xc0\xfa\x30\xcc\xb8\xc9")
+	r.appendASM(0x00, "compare room", `
+		ld a,($cc2d)
+		cp c
+		ret nz
+		ld a,($cc30)
+		cp b
+		ret`)
 
 	// read 2 bytes from bank e at hl into bc.
-	readWord := r.appendToBank(0x00, "read word",
-		"\xfa\x97\xff\xf5\x7b\xea\x97\xff\xea\x22\x22"+ // switch bank
-			"\x2a\x47\x7e\x4f"+ // read
-			"\xf1\xea\x97\xff\xea\x22\x22\xc9") // switch back
+	r.appendASM(0x00, "read word", `
+		ld a,($ff97) ; switch bank
+		push af
+		ld a,e
+		ld ($ff97),a
+		ld ($2222),a
+		ld a,(hl+) ; read
+		ld b,a
+		ld a,(hl)
+		ld c,a
+		pop af
+		ld ($ff97),a
+		ld ($2222),a
+		ret ; switch back`)
 
 	// searches for a value in a table starting at hl, with an entry matching
 	// keys b and subkey c, and values e bytes long. sets c if found. a key of
 	// ff ends the table.
-	searchDoubleKey := r.appendToBank(0x00, "search by double key",
-		"\x2a\xfe\xff\xc8\xb8\x20\x06\x2a\xb9\x20\x03\x37\xc9"+
-			"\x23\x7b\xd7\x18\xee")
+	r.appendASM(0x00, "search by double key", `
+	loop:
+		ld a,(hl+)
+		cp a,$ff
+		ret z
+		cp b
+		jr nz,next
+		ld a,(hl+)
+		cp c
+		jr nz,next2
+		scf
+		ret
+	next:
+		inc hl
+	next2:
+		ld a,e
+		rst $10
+		jr loop`)
 
 	// searches for an interaction with ID a and returns the ID address in de,
 	// and z flag if found.
-	findObjectWithID := r.appendToBank(0x00, "find object with ID",
-		"\xc5\x47\x11\x41\xd0\x1a\xb8\x20\x02\xc1\xc9"+
-			"\x14\x7a\xfe\xe0\x38\xf4\xc1\xb7\xc9")
+	r.appendASM(0x00, "find object with ID", `
+		push bc
+		ld b,a
+		ld de,$d041
+	loop:
+		ld a,(de)
+		cp b
+		jr nz,next
+		pop bc
+		ret
+	next:
+		inc d
+		ld a,d
+		cp a,$e0
+		jr c,loop
+		pop bc
+		or a
+		ret`)
 
 	// bank 01
 
 	// use a different invalid tile table for time warping if link doesn't have
 	// flippers.
-	noFlippersTable := r.appendToBank(0x01, "no flippers table",
+	r.appendToBank(0x01, "no flippers table",
 		"\xf3\x00\xfe\x00\xff\x00\xe4\x00\xe5\x00\xe6\x00\xe7\x00\xe8\x00"+
 			"\xe9\x00\xfc\x01\xfa\x00\xe0\x00\xe1\x00\xe2\x00\xe3\x00\x00")
-	dontDrownLink := r.appendToBank(0x01, "don't drown link",
-		"\x21\x17\x63\xfa\x9f\xc6\xe6\x40\xc0\x21"+noFlippersTable+"\xc9")
-	r.replace(0x01, 0x6301, "call don't drown link",
-		"\x21\x17\x63", "\xcd"+dontDrownLink)
+	r.appendASM(0x01, "don't drown link", `
+		ld hl,$6317
+		ld a,($c69f)
+		and $40
+		ret nz
+		ld hl,no_flippers_table
+		ret`)
+	r.replaceASM(0x01, 0x6301, "call don't drown link",
+		"\x21\x17\x63", "call dont_drown_link")
 
 	// bank 02
 
@@ -101,60 +177,176 @@ func initAgesEOB() {
 		"\xc2\xba\x4f", "\xc4"+treeWarp)
 
 	// warp to room under cursor if wearing developer ring.
-	devWarp := r.appendToBank(0x02, "dev ring warp func",
-		"\xfa\xcb\xc6\xfe\x40\x20\x12\xfa\x2d\xcc\xfe\x02\x30\x0b\xf6\x80"+
-			"\xea\x47\xcc\xfa\xb6\xcb\xea\x48\xcc\x3e\x03\xcd\xad\x0c\xc9")
+	devWarp := r.appendASM(0x02, "dev ring warp func", `
+		ld a,($c6cb)
+		cp a,$40
+		jr nz,next
+		ld a,($cc2d)
+		cp a,$02
+		jr nc,next
+		or $80
+		ld ($cc47),a
+		ld a,($cbb6)
+		ld ($cc48),a
+	next:
+		ld a,$03
+		call $0cad
+		ret`)
 	r.replace(0x02, 0x5fcc, "dev ring warp call", "\xad\x0c", devWarp)
 
 	// allow warping to south lynna tree even if it hasn't been visited (warp
 	// menu locks otherwise).
-	checkTreeVisited := r.appendToBank(0x02, "check tree visited",
-		"\xfe\x78\xc2\x39\x66\xb7\xc9")
-	r.replace(0x02, 0x5ff9, "call check tree visited 1",
-		"\xcd\x39\x66", "\xcd"+checkTreeVisited)
-	r.replace(0x02, 0x66a9, "call check tree visited 2",
-		"\xcd\x39\x66", "\xcd"+checkTreeVisited)
-	checkCursorVisited := r.appendToBank(0x02, "check cursor visited",
-		"\xfa\xb6\xcb\xfe\x78\xc2\x39\x66\xb7\xc9")
-	r.replace(0x02, 0x619d, "call check cursor visited",
-		"\xcd\x36\x66", "\xcd"+checkCursorVisited)
+	r.appendASM(0x02, "check tree visited", `
+		cp a,$78
+		jp nz,$6639
+		or a
+		ret`)
+	r.replaceASM(0x02, 0x5ff9, "call check tree visited 1",
+		"\xcd\x39\x66", "call check_tree_visited")
+	r.replaceASM(0x02, 0x66a9, "call check tree visited 2",
+		"\xcd\x39\x66", "call check_tree_visited")
+	r.appendASM(0x02, "check cursor visited", `
+		ld a,($cbb6)
+		cp a,$78
+		jp nz,$6639
+		or a
+		ret`)
+	r.replaceASM(0x02, 0x619d, "call check cursor visited",
+		"\xcd\x36\x66", "call check_cursor_visited")
 
 	// display portal popup map icons for bridge builders' screen present and
 	// symmetry city past.
-	displayPortalPopup := r.appendToBank(0x02, "display portal popup",
-		"\xfa\xb3\xcb\xa7\xfa\xb6\xcb\x20\x08\xfe\x25\x20\x0d\x3e\xaa\x18\x06"+
-			"\xfe\x13\x20\x05\x3e\xa3\xc3\x55\x62\xc3\x48\x62")
-	r.replace(0x02, 0x6245, "jump display portal popup",
-		"\xfa\xb6\xcb", "\xc3"+displayPortalPopup)
+	r.appendASM(0x02, "display portal popup", `
+		ld a,($cbb3)
+		and a
+		ld a,($cbb6)
+		jr nz,next
+		cp a,$25
+		jr nz,next2
+		ld a,$aa
+		jr next3
+	next:
+		cp a,$13
+		jr nz,next2
+		ld a,$a3
+	next3:
+		jp $6255
+	next2:
+		jp $6248`)
+	r.replaceASM(0x02, 0x6245, "jump display portal popup",
+		"\xfa\xb6\xcb", "jp display_portal_popup")
 
 	// bank 03
 
 	// allow skipping the capcom screen after one second by pressing start
-	skipCapcom := r.appendToBank(0x03, "skip capcom func",
-		"\xe5\xfa\xb3\xcb\xfe\x94\x30\x03\xcd\x86\x08\xe1\xcd\x37\x02\xc9")
+	skipCapcom := r.appendASM(0x03, "skip capcom func", `
+		push hl
+		ld a,($cbb3)
+		cp a,$94
+		jr nc,next
+		call $0886
+	next:
+		pop hl
+		call $0237
+		ret`)
 	r.replace(0x03, 0x4d6c, "skip capcom call", "\x37\x02", skipCapcom)
 
 	// set flags to skip opening and a bunch of other things. see
 	// doc/technical.md for a dictionary of the flags.
-	initialGlobalFlags := r.appendToBank(0x03, "initial global flags",
+	r.appendToBank(0x03, "initial global flags",
 		"\x0a\x0c\x1d\x20\x23\x2b\x33\x3d\x40\x41\x43\x45\xff")
-	skipOpening := r.appendToBank(0x03, "skip opening",
-		"\xe5\x21"+initialGlobalFlags+"\x2a\xfe\xff\x28\x07"+
-			"\xe5\xcd\xf9\x31\xe1\x18\xf4"+ // init global flags
-			"\xfa\xff\x7f\xea\x10\xc6\x3e\x03\xea\x47\xc6"+ // set animal stuff
-			"\x3e\xff\xea\x49\xc6"+
-			"\x3e\x01\xea\xe8\xc6"+ // make maku tree vanish
-			"\x3e\x40\xea\x7a\xc7\xea\x6a\xc7\xea\x59\xc7"+ // room flag 6
-			"\xea\x7c\xc7\xea\x2e\xc7\xea\x97\xc8\xea\x3a\xc7\xea\xba\xc7"+
-			"\xea\x8d\xc7\xea\x0a\xc7\xea\xf6\xc9\xea\x5c\xc8\xea\x83\xc8"+
-			"\xea\x0f\xc8\xea\x03\xc7\xea\x90\xc7\xea\x7b\xc7"+
-			"\x3e\x08\xea\x25\xc7\xea\x13\xc8\xea\xbd\xc9"+ // room flag 3
-			"\xea\x6e\xca"+
-			"\x3e\x01\xea\x76\xc8\xea\x38\xc7"+ // room flag 1
-			"\x3e\xc8\xea\x39\xc7\x3e\x02\xea\x6d\xca"+ // other rooms
-			"\xe1\xc9")
-	r.replace(0x03, 0x6e97, "call skip opening",
-		"\xc3\xf9\x31", "\xc3"+skipOpening)
+	// boss keys to OR into c682-c683, if boss keys are removed from the pool.
+	r.appendToBank(0x03, "starting boss keys", "\x00\x00")
+	// (ID, param) pairs of treasures to give at the start of the game,
+	// terminated by ff.
+	r.appendToBank(0x03, "starting items",
+		strings.Repeat("\xff", 2*maxStartingItems+1))
+	r.appendASM(0x03, "give starting items", `
+		push bc ; push registers, load table
+		push de
+		push hl
+		ld hl,starting_items
+	loop:
+		ld a,(hl+) ; read ID and param, or end
+		cp a,$ff
+		jr z,next
+		ld c,(hl)
+		inc hl
+		push hl ; give treasure and loop
+		call $171c
+		pop hl
+		jr loop
+	next:
+		pop hl
+		pop de
+		pop bc
+		ret`)
+	r.appendASM(0x03, "skip opening", `
+		push hl
+		ld hl,initial_global_flags
+	loop:
+		ld a,(hl+)
+		cp a,$ff
+		jr z,next
+		push hl ; init global flags
+		call $31f9
+		pop hl
+		jr loop
+	next:
+		ld a,($7fff) ; set animal stuff
+		ld ($c610),a
+		ld a,$03
+		ld ($c647),a
+		ld a,$ff
+		ld ($c649),a
+		ld a,$01 ; make maku tree vanish
+		ld ($c6e8),a
+		ld a,$40 ; room flag 6
+		ld ($c77a),a
+		ld ($c76a),a
+		ld ($c759),a
+		ld ($c77c),a
+		ld ($c72e),a
+		ld ($c897),a
+		ld ($c73a),a
+		ld ($c7ba),a
+		ld ($c78d),a
+		ld ($c70a),a
+		ld ($c9f6),a
+		ld ($c85c),a
+		ld ($c883),a
+		ld ($c80f),a
+		ld ($c703),a
+		ld ($c790),a
+		ld ($c77b),a
+		ld a,$08 ; room flag 3
+		ld ($c725),a
+		ld ($c813),a
+		ld ($c9bd),a
+		ld ($ca6e),a
+		ld a,$01 ; room flag 1
+		ld ($c876),a
+		ld ($c738),a
+		ld a,$c8 ; other rooms
+		ld ($c739),a
+		ld a,$02
+		ld ($ca6d),a
+		call give_starting_items
+		push de ; boss keys
+		ld de,starting_boss_keys
+		ld hl,$c682
+		ld a,(de)
+		or (hl)
+		ld (hl+),a
+		inc de
+		ld a,(de)
+		or (hl)
+		ld (hl),a
+		pop de
+		pop hl
+		ret`)
+	r.replaceASM(0x03, 0x6e97, "call skip opening",
+		"\xc3\xf9\x31", "jp skip_opening")
 
 	// bank 04
 
@@ -162,7 +354,7 @@ func initAgesEOB() {
 	// format is (group, room, bitmask, YX, tile ID), with ff ending the table.
 	// if the bitmask AND the current room flags is nonzero, the replacement is
 	// not made.
-	tileReplaceTable := r.appendToBank(0x04, "tile replace table",
+	r.appendToBank(0x04, "tile replace table",
 		"\x01\x48\x00\x45\xd7"+ // portal south of past maku tree
 			"\x00\x39\x00\x63\xf0"+ // open chest on intro screen
 			"\x00\x39\x20\x63\xf1"+ // closed chest on intro screen
@@ -190,89 +382,224 @@ func initAgesEOB() {
 			"\x01\xa5\x00\x45\x0b"+ // cont.
 			"\x01\xa5\x00\x55\x6c"+ // cont.
 			"\xff")
-	tileReplaceFunc := r.appendToBank(0x04, "tile replace body",
-		"\xc5\xd5\xcd\x7d\x19\x5f\x21"+tileReplaceTable+"\xfa\x2d\xcc\x47"+
-			"\xfa\x30\xcc\x4f"+ // load room flags, table addr, group, room
-			"\x2a\xfe\xff\x28\x1b\xb8\x20\x12\x2a\xb9\x20\x0f"+
-			"\x2a\xa3\x20\x0c"+ // compare group, room, flags
-			"\xd5\x16\xcf\x2a\x5f\x2a\x12\xd1\x18\xe6"+ // replace
-			"\x23\x23\x23\x23\x18\xe0\xd1\xc1\xcd\xef\x5f\xc9")
-	r.replace(0x00, 0x38c0, "tile replace call",
-		"\xcd\xef\x5f", "\xcd"+tileReplaceFunc)
+	r.appendASM(0x04, "tile replace body", `
+		push bc
+		push de
+		call $197d
+		ld e,a
+		ld hl,tile_replace_table
+		ld a,($cc2d)
+		ld b,a
+		ld a,($cc30) ; load room flags, table addr, group, room
+		ld c,a
+	loop:
+		ld a,(hl+)
+		cp a,$ff
+		jr z,next
+		cp b
+		jr nz,next2
+		ld a,(hl+)
+		cp c
+		jr nz,next3
+		ld a,(hl+) ; compare group, room, flags
+		and e
+		jr nz,next4
+		push de ; replace
+		ld d,$cf
+		ld a,(hl+)
+		ld e,a
+		ld a,(hl+)
+		ld (de),a
+		pop de
+		jr loop
+	next2:
+		inc hl
+	next3:
+		inc hl
+	next4:
+		inc hl
+		inc hl
+		jr loop
+	next:
+		pop de
+		pop bc
+		call $5fef
+		ret`)
+	r.replaceASM(0x00, 0x38c0, "tile replace call",
+		"\xcd\xef\x5f", "call tile_replace_body")
 
 	// treat the d2 present entrance like the d2 past entrance.
-	replaceWarpEnter := r.appendToBank(0x04, "replace warp enter",
-		"\xc5\x01\x00\x83\xcd"+compareRoom+"\xc1\xfa\x2d\xcc\xc0\x3c\xc9")
-	r.replace(0x04, 0x4630, "call replace warp enter",
-		"\xfa\x2d\xcc", "\xcd"+replaceWarpEnter)
+	r.appendASM(0x04, "replace warp enter", `
+		push bc
+		ld bc,$8300
+		call compare_room
+		pop bc
+		ld a,($cc2d)
+		ret nz
+		inc a
+		ret`)
+	r.replaceASM(0x04, 0x4630, "call replace warp enter",
+		"\xfa\x2d\xcc", "call replace_warp_enter")
 	// and exit into the present if there's a portal in the present entrance.
-	replaceWarpExit := r.appendToBank(0x00, "replace warp exit",
-		"\xea\x48\xcc\xfe\x83\xc0\xfa\x2d\xcc\xfe\x04\xc0"+
-			"\xfa\x3e\xc6\xb7\xc0\xfa\x3f\xc6\xfe\x83\xc0"+
-			"\xfa\x47\xcc\xe6\xf0\xea\x47\xcc\xc9")
-	r.replace(0x04, 0x45e8, "call replace warp exit normal",
-		"\xea\x48\xcc", "\xcd"+replaceWarpExit)
-	r.replace(0x0a, 0x4738, "call replace warp exit essence",
-		"\xea\x48\xcc", "\xcd"+replaceWarpExit)
+	r.appendASM(0x00, "replace warp exit", `
+		ld ($cc48),a
+		cp a,$83
+		ret nz
+		ld a,($cc2d)
+		cp a,$04
+		ret nz
+		ld a,($c63e)
+		or a
+		ret nz
+		ld a,($c63f)
+		cp a,$83
+		ret nz
+		ld a,($cc47)
+		and $f0
+		ld ($cc47),a
+		ret`)
+	r.replaceASM(0x04, 0x45e8, "call replace warp exit normal",
+		"\xea\x48\xcc", "call replace_warp_exit")
+	r.replaceASM(0x0a, 0x4738, "call replace warp exit essence",
+		"\xea\x48\xcc", "call replace_warp_exit")
 
 	// bank 05
 
 	// if wearing dev ring, jump over any tile like a ledge by pressing B with
 	// no B item equipped.
-	devJump := r.appendToBank(0x05, "dev jump",
-		"\xf5\xfa\xcb\xc6\xfe\x40\x20\x13"+ // check ring
-			"\xfa\x88\xc6\xb7\x20\x0d"+ // check B item
-			"\xfa\x81\xc4\xe6\x02\x28\x06"+ // check input
-			"\xf1\xfa\x09\xd0\x37\xc9\xf1\xc9") // jump over ledge
-	graveJump := r.appendToBank(0x05, "grave jump",
-		"\xf5\xfe\x84\x20\x0f"+ // tile
-			"\xc5\x01\x00\x5b\xcd"+compareRoom+"\xc1\x20\x05"+ // room
-			"\xf1\x3e\x10\x37\xc9\xf1\xc9") // jump
-	cliffLookup := r.appendToBank(0x05, "cliff lookup",
-		"\xcd"+devJump+"\xd8\xcd"+graveJump+"\xd8\xc3\x1f\x1e")
-	r.replace(0x05, 0x6083, "call cliff lookup",
-		"\xcd\x1f\x1e", "\xcd"+cliffLookup)
+	r.appendASM(0x05, "dev jump", `
+		push af ; check ring
+		ld a,($c6cb)
+		cp a,$40
+		jr nz,next
+		ld a,($c688) ; check B item
+		or a
+		jr nz,next
+		ld a,($c481) ; check input
+		and $02
+		jr z,next
+		pop af
+		ld a,($d009)
+		scf
+		ret
+	next:
+		pop af
+		ret ; jump over ledge`)
+	r.appendASM(0x05, "grave jump", `
+		push af ; tile
+		cp a,$84
+		jr nz,next
+		push bc ; room
+		ld bc,$5b00
+		call compare_room
+		pop bc
+		jr nz,next
+		pop af
+		ld a,$10
+		scf
+		ret
+	next:
+		pop af
+		ret ; jump`)
+	r.appendASM(0x05, "cliff lookup", `
+		call dev_jump
+		ret c
+		call grave_jump
+		ret c
+		jp $1e1f`)
+	r.replaceASM(0x05, 0x6083, "call cliff lookup",
+		"\xcd\x1f\x1e", "call cliff_lookup")
 
 	// prevent link from surfacing from underwater without mermaid suit. this
 	// is probably only relevant for the sea of no return.
-	preventSurface := r.appendToBank(0x05, "prevent surface",
-		"\xfa\x91\xcc\xb7\xc0\xfa\xa3\xc6\xe6\x04\xfe\x04\xc9")
-	r.replace(0x05, 0x516c, "call prevent surface",
-		"\xfa\x91\xcc\xb7", "\xcd"+preventSurface+"\x00")
+	r.appendASM(0x05, "prevent surface", `
+		ld a,($cc91)
+		or a
+		ret nz
+		ld a,($c6a3)
+		and $04
+		cp a,$04
+		ret`)
+	r.replaceASM(0x05, 0x516c, "call prevent surface",
+		"\xfa\x91\xcc\xb7", `
+		call prevent_surface
+		nop`)
 
 	// bank 06
 
 	// burning the first tree in yoll graveyard should set room flag 1 so that
 	// it can be gone for good.
-	removeYollTree := r.appendToBank(0x06, "remove yoll tree",
-		"\xf5\xf0\x8f\xfe\x0c\x20\x0f"+
-			"\xc5\x01\x00\x6b\xcd"+compareRoom+"\x20\x05"+
-			"\x21\x6b\xc7\xcb\xce\xc1\xf1\x21\x26\xc6\xc9")
-	r.replace(0x06, 0x47aa, "call remove yoll tree",
-		"\x21\x26\xc6", "\xcd"+removeYollTree)
+	r.appendASM(0x06, "remove yoll tree", `
+		push af
+		ldh a,($8f)
+		cp a,$0c
+		jr nz,next
+		push bc
+		ld bc,$6b00
+		call compare_room
+		jr nz,next2
+		ld hl,$c76b
+		set 1,(hl)
+	next2:
+		pop bc
+	next:
+		pop af
+		ld hl,$c626
+		ret`)
+	r.replaceASM(0x06, 0x47aa, "call remove yoll tree",
+		"\x21\x26\xc6", "call remove_yoll_tree")
 
 	// reenter a warp tile that link is standing on when playing the tune of
 	// currents (useful if you warp into a patch of bushes). also activate the
 	// west present crescent island portal.
-	reenterCurrentsWarp := r.appendToBank(0x06, "special currents actions",
-		"\xc5\x01\x00\xa9\xcd"+compareRoom+"\xc1\x20\x11"+ // island portal
-			"\xd5\x3e\xe1\xcd"+findObjectWithID+"\x20\x05"+ // cont.
-			"\x1e\x44\x3e\x02\x12\xd1\xc3\x08\x4e"+ // cont.
-			"\xfa\x34\xcc\xf5\xd5\x3e\xde\xcd"+findObjectWithID+ // reenter
-			"\x20\x05\x1e\x44\x3e\x02\x12\xd1\xf1\xc3\x37\x4e") // cont.
-	r.replace(0x06, 0x4e34, "call special currents actions",
-		"\xfa\x34\xcc", "\xc3"+reenterCurrentsWarp)
+	r.appendASM(0x06, "special currents actions", `
+		push bc ; island portal
+		ld bc,$a900
+		call compare_room
+		pop bc
+		jr nz,next
+		push de ; cont.
+		ld a,$e1
+		call find_object_with_id
+		jr nz,next2
+		ld e,$44 ; cont.
+		ld a,$02
+		ld (de),a
+	next2:
+		pop de
+		jp $4e08
+	next:
+		ld a,($cc34) ; reenter
+		push af
+		push de
+		ld a,$de
+		call find_object_with_id
+		jr nz,next3
+		ld e,$44
+		ld a,$02
+		ld (de),a
+	next3:
+		pop de
+		pop af
+		jp $4e37 ; cont.`)
+	r.replaceASM(0x06, 0x4e34, "call special currents actions",
+		"\xfa\x34\xcc", "jp special_currents_actions")
 
 	// set text index for portal sign on crescent island.
-	setPortalSignText := r.appendToBank(0x06, "set portal sign text",
-		"\x01\x00\xa9\xcd"+compareRoom+"\x01\x01\x09\xc0\x01\x01\x56\xc9")
-	r.replace(0x06, 0x40e7, "call set portal sign text",
-		"\x01\x01\x09", "\xcd"+setPortalSignText)
+	r.appendASM(0x06, "set portal sign text", `
+		ld bc,$a900
+		call compare_room
+		ld bc,$0901
+		ret nz
+		ld bc,$5601
+		ret`)
+	r.replaceASM(0x06, 0x40e7, "call set portal sign text",
+		"\x01\x01\x09", "call set_portal_sign_text")
 
 	// bank 16 (pt. 1)
 
 	// upgraded item data (one byte for old ID, one for new ID two for address):
-	progItemAddrs := r.appendToBank(0x16, "progressive item addrs",
+	r.appendToBank(0x16, "progressive item addrs",
 		"\x05\x05\xea\x54"+ // noble sword
 			"\x0a\x0a\x12\x55"+ // long switch
 			"\x16\x16\x52\x55"+ // power glove
@@ -284,166 +611,430 @@ func initAgesEOB() {
 	// given a treasure ID in b, make hl = the start of the upgraded treasure
 	// data + 1, if the treasure needs to be upgraded, and returns the new
 	// treasure ID in b.
-	getUpgradedTreasure := r.appendToBank(0x16, "get upgraded treasure",
-		"\x78\xcd\x48\x17\x78\xd0"+ // check obtained
-			"\xfe\x25\x20\x09\x3e\x26\x5f\xcd\x48\x17\x30\x01\x43"+ // harp
-			"\xe5\x21"+progItemAddrs+"\x2a\xfe\xff\x28\x13"+ // search
-			"\xb8\x20\x06\x2a\x47\x2a\x5e\x18\x05\x23\x23\x23\x18\xed"+
-			"\xe1\x63\x6f\x23\xc9\xe1\xc9") // done
+	r.appendASM(0x16, "get upgraded treasure", `
+		ld a,b ; check obtained
+		call $1748
+		ld a,b
+		ret nc
+		cp a,$25 ; harp
+		jr nz,next
+		ld a,$26
+		ld e,a
+		call $1748
+		jr nc,next
+		ld b,e
+	next:
+		push hl ; search
+		ld hl,progressive_item_addrs
+	loop:
+		ld a,(hl+)
+		cp a,$ff
+		jr z,next2
+		cp b
+		jr nz,next3
+		ld a,(hl+)
+		ld b,a
+		ld a,(hl+)
+		ld e,(hl)
+		jr next4
+	next3:
+		inc hl
+		inc hl
+		inc hl
+		jr loop
+	next4:
+		pop hl
+		ld h,e
+		ld l,a
+		inc hl
+		ret
+	next2:
+		pop hl
+		ret ; done`)
 	// load the address of a treasure's 4-byte data entry + 1 into hl, using b
 	// as the ID and c as sub ID, accounting for progressive upgrades.
-	getTreasureDataBody := r.appendToBank(0x16, "get treasure data body",
-		"\x21\x32\x53\x78\x87\xd7\x78\x87\xd7\xcb\x7e\x28\x04"+
-			"\x23\x2a\x66\x6f\x79\x87\x87\xd7\x23\xc3"+getUpgradedTreasure)
+	r.appendASM(0x16, "get treasure data body", `
+		ld hl,$5332
+		ld a,b
+		add a,a
+		rst $10
+		ld a,b
+		add a,a
+		rst $10
+		bit 7,(hl)
+		jr z,next
+		inc hl
+		ld a,(hl+)
+		ld h,(hl)
+		ld l,a
+	next:
+		ld a,c
+		add a,a
+		add a,a
+		rst $10
+		inc hl
+		jp get_upgraded_treasure`)
 	// do the above and put the ID, param, and text in b, c, and e.
-	getTreasureDataBCE := r.appendToBank(0x16, "get treasure data bc",
-		"\xcd"+getTreasureDataBody+"\x4e\x23\x5e\xc9")
-	getTreasureData := r.appendToBank(0x00, "get treasure data",
-		"\x1e\x16\x21"+getTreasureDataBCE+"\xc3\x8a\x00")
+	r.appendASM(0x16, "get treasure data bc", `
+		call get_treasure_data_body
+		ld c,(hl)
+		inc hl
+		ld e,(hl)
+		ret`)
+	r.appendASM(0x00, "get treasure data", `
+		ld e,$16
+		ld hl,get_treasure_data_bc
+		jp $008a`)
 
 	// bank 09
 
 	// set treasure ID 07 (rod of seasons) when buying the 150 rupee shop item,
 	// so that the shop can check this specific ID.
-	shopSetFakeID := r.appendToBank(0x09, "shop set fake ID",
-		"\xfe\x0d\x20\x05\x21\x9a\xc6\xcb\xfe\x21\xf7\x44\xc9")
-	r.replace(0x09, 0x4418, "call shop set fake ID",
-		"\x21\xf7\x44", "\xcd"+shopSetFakeID)
+	r.appendASM(0x09, "shop set fake ID", `
+		cp a,$0d
+		jr nz,next
+		ld hl,$c69a
+		set 7,(hl)
+	next:
+		ld hl,$44f7
+		ret`)
+	r.replaceASM(0x09, 0x4418, "call shop set fake ID",
+		"\x21\xf7\x44", "call shop_set_fake_id")
 
 	// set treasure ID 08 (magnet gloves) when getting item from south shore
 	// dirt pile.
-	digSetFakeID := r.appendToBank(0x09, "dirt set fake ID",
-		"\xc5\x01\x00\x98\xcd"+compareRoom+"\xc1\xc0\xe5\x21\x9b\xc6\xcb\xc6"+
-			"\xe1\xc9")
+	r.appendASM(0x09, "dirt set fake ID", `
+		push bc
+		ld bc,$9800
+		call compare_room
+		pop bc
+		ret nz
+		push hl
+		ld hl,$c69b
+		set 0,(hl)
+		pop hl
+		ret`)
 	// set treasure ID 13 (slingshot) when getting first item from tingle.
-	tingleSetFakeID := r.appendToBank(0x09, "tingle set fake ID",
-		"\xc5\x01\x00\x79\xcd"+compareRoom+"\xc1\xc0\xe5\x21\x9c\xc6\xcb\xde"+
-			"\xe1\xc9")
+	r.appendASM(0x09, "tingle set fake ID", `
+		push bc
+		ld bc,$7900
+		call compare_room
+		pop bc
+		ret nz
+		push hl
+		ld hl,$c69c
+		set 3,(hl)
+		pop hl
+		ret`)
 	// set treasure ID 1e (fool's ore) for symmetry city brother.
-	brotherSetFakeID := r.appendToBank(0x09, "brother set fake ID",
-		"\xc5\x01\x03\x6e\xcd"+compareRoom+"\x28\x04\x04\xcd"+compareRoom+
-			"\xc1\xc0\xe5\x21\x9d\xc6\xcb\xf6\xe1\xc9")
+	r.appendASM(0x09, "brother set fake ID", `
+		push bc
+		ld bc,$6e03
+		call compare_room
+		jr z,next
+		inc b
+		call compare_room
+	next:
+		pop bc
+		ret nz
+		push hl
+		ld hl,$c69d
+		set 6,(hl)
+		pop hl
+		ret`)
 	// set treasure ID 10 (nothing) for king zora.
-	kingZoraSetFakeID := r.appendToBank(0x09, "king zora set fake ID",
-		"\xc5\x01\x05\xab\xcd"+compareRoom+"\xc1\xc0\xe5\x21\x9c\xc6\xcb\xc6"+
-			"\xe1\xc9")
+	r.appendASM(0x09, "king zora set fake ID", `
+		push bc
+		ld bc,$ab05
+		call compare_room
+		pop bc
+		ret nz
+		push hl
+		ld hl,$c69c
+		set 0,(hl)
+		pop hl
+		ret`)
 	// set treasure ID 12 (nothing) for first goron dance, and 14 (nothing) for
 	// the second. if you're in the present, it's always 12. if you're in the
 	// past, it's 12 iff you don't have letter of introduction.
-	goronDanceSetFakeID := r.appendToBank(0x09, "dance 1 set fake ID",
-		"\xc5\x01\x02\xed\xcd"+compareRoom+"\xc1\x28\x12"+ // present
-			"\xc5\x01\x02\xef\xcd"+compareRoom+"\xc1\xc0"+ // past
-			"\x3e\x59\xcd\x48\x17\x3e\x10\x38\x02\x3e\x04"+
-			"\xe5\x21\x9c\xc6\xb6\x77\xe1\xc9")
+	r.appendASM(0x09, "dance 1 set fake ID", `
+		push bc ; present
+		ld bc,$ed02
+		call compare_room
+		pop bc
+		jr z,next
+		push bc ; past
+		ld bc,$ef02
+		call compare_room
+		pop bc
+		ret nz
+		ld a,$59
+		call $1748
+		ld a,$10
+		jr c,next2
+	next:
+		ld a,$04
+	next2:
+		push hl
+		ld hl,$c69c
+		or (hl)
+		ld (hl),a
+		pop hl
+		ret`)
 	// set flag for d6 past and present boss keys whether you get the key in
 	// past or present.
-	setD6BossKey := r.appendToBank(0x09, "set d6 boss key",
-		"\x7b\xfe\x31\xc0\xfa\x39\xcc\xfe\x06\x28\x03\xfe\x0c\xc0"+
-			"\xe5\x21\x82\xc6\xcb\xf6\x23\xcb\xe6\xe1\xc9")
+	r.appendASM(0x09, "set d6 boss key", `
+		ld a,e
+		cp a,$31
+		ret nz
+		ld a,($cc39)
+		cp a,$06
+		jr z,next
+		cp a,$0c
+		ret nz
+	next:
+		push hl
+		ld hl,$c682
+		set 6,(hl)
+		inc hl
+		set 4,(hl)
+		pop hl
+		ret`)
 	// refill all seeds when picking up a seed satchel.
-	refillSeedSatchel := r.appendToBank(0x09, "refill seed satchel",
-		"\x7b\xfe\x19\xc0"+
-			"\xc5\xd5\xe5\x21\xb4\xc6\x34\xcd\x0c\x18\x35\xe1\xd1\xc1\xc9")
+	r.appendASM(0x09, "refill seed satchel", `
+		ld a,e
+		cp a,$19
+		ret nz
+		push bc
+		push de
+		push hl
+		ld hl,$c6b4
+		inc (hl)
+		call $180c
+		dec (hl)
+		pop hl
+		pop de
+		pop bc
+		ret`)
 	// give 20 seeds when picking up the seed shooter.
-	fillSeedShooter := r.appendToBank(0x09, "fill seed shooter",
-		"\x7b\xfe\x0f\xc0\xc5\x3e\x20\x0e\x20\xcd\x1c\x17\xc1\xc9")
+	r.appendASM(0x09, "fill seed shooter", `
+		ld a,e
+		cp a,$0f
+		ret nz
+		push bc
+		ld a,$20
+		ld c,$20
+		call $171c
+		pop bc
+		ret`)
 	// give flute the correct icon and make it functional from the start.
-	activateFlute := r.appendToBank(0x09, "activate flute",
-		"\x7b\xfe\x0e\xc0"+
-			"\x79\xd6\x0a\xea\xb5\xc6\xe5\x26\xc6\xc6\x45\x6f\x36\xc3\xe1\xc9")
+	r.appendASM(0x09, "activate flute", `
+		ld a,e
+		cp a,$0e
+		ret nz
+		ld a,c
+		sub $0a
+		ld ($c6b5),a
+		push hl
+		ld h,$c6
+		add a,$45
+		ld l,a
+		ld (hl),$c3
+		pop hl
+		ret`)
 	// reset maku tree to state 02 after getting the maku seed.
-	makuSeedResetState := r.appendToBank(0x09, "maku seed reset state",
-		"\x7b\xfe\x36\xc0\x3e\x02\xea\xe8\xc6\xc9")
+	r.appendASM(0x09, "maku seed reset state", `
+		ld a,e
+		cp a,$36
+		ret nz
+		ld a,$02
+		ld ($c6e8),a
+		ret`)
 	// this function checks all the above conditions when collecting an item.
-	handleGetItem := r.appendToBank(0x09, "handle get item",
-		"\x5f\xcd"+digSetFakeID+"\xcd"+setD6BossKey+"\xcd"+refillSeedSatchel+
-			"\xcd"+fillSeedShooter+"\xcd"+activateFlute+"\xcd"+tingleSetFakeID+
-			"\xcd"+brotherSetFakeID+"\xcd"+kingZoraSetFakeID+
-			"\xcd"+goronDanceSetFakeID+"\xcd"+makuSeedResetState+
-			"\x7b\xc3\x1c\x17")
-	r.replace(0x09, 0x4c4e, "call handle get item",
-		"\xcd\x1c\x17", "\xcd"+handleGetItem)
+	r.appendASM(0x09, "handle get item", `
+		ld e,a
+		call dirt_set_fake_id
+		call set_d6_boss_key
+		call refill_seed_satchel
+		call fill_seed_shooter
+		call activate_flute
+		call tingle_set_fake_id
+		call brother_set_fake_id
+		call king_zora_set_fake_id
+		call dance_1_set_fake_id
+		call maku_seed_reset_state
+		ld a,e
+		jp $171c`)
+	r.replaceASM(0x09, 0x4c4e, "call handle get item",
+		"\xcd\x1c\x17", "call handle_get_item")
 
 	// don't set room's item flag if it's nayru's item on the maku tree screen,
 	// since link still might not have taken the maku tree's item.
-	makuTreeItemFlag := r.appendToBank(0x09, "maku tree item flag",
-		"\xcd\x7d\x19\xc5\x01\x38\xc7\xcd\xd6\x01\xc1\x20\x06\xfa\x0d\xd0"+
-			"\xfe\x50\xc8\xcb\xee\xc9")
-	r.replace(0x09, 0x4c82, "call maku tree item flag",
-		"\xcd\x7d\x19", "\xc3"+makuTreeItemFlag)
+	r.appendASM(0x09, "maku tree item flag", `
+		call $197d
+		push bc
+		ld bc,$c738
+		call $01d6
+		pop bc
+		jr nz,next
+		ld a,($d00d)
+		cp a,$50
+		ret z
+	next:
+		set 5,(hl)
+		ret`)
+	r.replaceASM(0x09, 0x4c82, "call maku tree item flag",
+		"\xcd\x7d\x19", "jp maku_tree_item_flag")
 
 	// give correct ID and param for shop item, play sound, and load correct
 	// text index into temp wram address.
-	shopGiveTreasure := r.appendToBank(0x09, "shop give treasure",
-		"\x47\x1a\xfe\x0d\x78\x20\x08\xcd"+getTreasureData+"\x7b\xea\x0d\xcf"+
-			"\x78\xcd"+handleGetItem+"\xc2\x98\x0c\x3e\x4c\xc3\x98\x0c")
-	r.replace(0x09, 0x4425, "call shop give treasure",
-		"\xcd\x1c\x17", "\xcd"+shopGiveTreasure)
+	r.appendASM(0x09, "shop give treasure", `
+		ld b,a
+		ld a,(de)
+		cp a,$0d
+		ld a,b
+		jr nz,next
+		call get_treasure_data
+		ld a,e
+		ld ($cf0d),a
+		ld a,b
+	next:
+		call handle_get_item
+		jp nz,$0c98
+		ld a,$4c
+		jp $0c98`)
+	r.replaceASM(0x09, 0x4425, "call shop give treasure",
+		"\xcd\x1c\x17", "call shop_give_treasure")
 	// display text based on above temp wram address.
-	shopShowText := r.appendToBank(0x09, "shop show text",
-		"\x1a\xfe\x0d\xc2\x72\x18\xfa\x0d\xcf\x06\x00\x4f\xc3\x72\x18")
-	r.replace(0x09, 0x4443, "call shop show text",
-		"\xc2\x72\x18", "\xc2"+shopShowText)
+	r.appendASM(0x09, "shop show text", `
+		ld a,(de)
+		cp a,$0d
+		jp nz,$1872
+		ld a,($cf0d)
+		ld b,$00
+		ld c,a
+		jp $1872`)
+	r.replaceASM(0x09, 0x4443, "call shop show text",
+		"\xc2\x72\x18", "jp nz,shop_show_text")
 
 	// bank 0a
 
 	// make ricky appear if you have his gloves, without giving rafton rope.
-	checkRickyAppear := r.appendToBank(0x0a, "check ricky appear",
-		"\xcd\xf3\x31\xc0\xfa\xa3\xc6\xcb\x47\xc0\xfa\x46\xc6\xb7\xc9")
-	r.replace(0x0a, 0x4bb8, "call check ricky appear",
-		"\xcd\xf3\x31", "\xcd"+checkRickyAppear)
+	r.appendASM(0x0a, "check ricky appear", `
+		call $31f3
+		ret nz
+		ld a,($c6a3)
+		bit 0,a
+		ret nz
+		ld a,($c646)
+		or a
+		ret`)
+	r.replaceASM(0x0a, 0x4bb8, "call check ricky appear",
+		"\xcd\xf3\x31", "call check_ricky_appear")
 
 	// require giving rafton rope, even if you have the island chart.
-	checkRaftonRope := r.appendToBank(0x0a, "check rafton rope",
-		"\xcd\x48\x17\xd0\x3e\x15\xcd\xf3\x31\xc8\x37\xc9")
-	r.replace(0x0a, 0x4d5f, "call check rafton rope",
-		"\xcd\x48\x17", "\xcd"+checkRaftonRope)
+	r.appendASM(0x0a, "check rafton rope", `
+		call $1748
+		ret nc
+		ld a,$15
+		call $31f3
+		ret z
+		scf
+		ret`)
+	r.replaceASM(0x0a, 0x4d5f, "call check rafton rope",
+		"\xcd\x48\x17", "call check_rafton_rope")
 
 	// set sub ID for south shore dig item.
-	dirtSpawnItem := r.appendToBank(0x0a, "dirt spawn item",
-		"\xcd\xd4\x27\xc0\xcd\x42\x22\xaf\xc9")
-	r.replace(0x0a, 0x5e3e, "call dirt spawn item",
-		"\xcd\xc5\x24", "\xcd"+dirtSpawnItem)
+	r.appendASM(0x0a, "dirt spawn item", `
+		call $27d4
+		ret nz
+		call $2242
+		xor a
+		ret`)
+	r.replaceASM(0x0a, 0x5e3e, "call dirt spawn item",
+		"\xcd\xc5\x24", "call dirt_spawn_item")
 
 	// automatically save maku tree when saving nayru.
-	saveMakuTreeWithNayru := r.appendToBank(0x0a, "save maku tree with nayru",
-		"\xcd\xf9\x31\xfa\xe8\xc6\xfe\x0e\x28\x02\x3e\x02\x3d\xea\xe8\xc6"+
-			"\x3e\x0c\xcd\xf9\x31\x3e\x12\xcd\xf9\x31\x3e\x3f\xcd\xf9\x31"+
-			"\xe5\x21\x38\xc7\xcb\x86\x24\xcb\xfe\x2e\x48\xcb\xc6\xe1\xc9")
-	r.replace(0x0a, 0x5541, "call save maku tree with nayru",
-		"\xcd\xf9\x31", "\xcd"+saveMakuTreeWithNayru)
+	r.appendASM(0x0a, "save maku tree with nayru", `
+		call $31f9
+		ld a,($c6e8)
+		cp a,$0e
+		jr z,next
+		ld a,$02
+	next:
+		dec a
+		ld ($c6e8),a
+		ld a,$0c
+		call $31f9
+		ld a,$12
+		call $31f9
+		ld a,$3f
+		call $31f9
+		push hl
+		ld hl,$c738
+		res 0,(hl)
+		inc h
+		set 7,(hl)
+		ld l,$48
+		set 0,(hl)
+		pop hl
+		ret`)
+	r.replaceASM(0x0a, 0x5541, "call save maku tree with nayru",
+		"\xcd\xf9\x31", "call save_maku_tree_with_nayru")
 
 	// use a non-cutscene screen transition for exiting a dungeon via essence,
 	// so that overworld music plays, and set maku tree state.
-	essenceWarp := r.appendToBank(0x0a, "essence warp",
-		"\x3e\x81\xea\x4b\xcc\xc3\x53\x3e")
-	r.replace(0x0a, 0x4745, "call essence warp",
-		"\xea\x4b\xcc", "\xcd"+essenceWarp)
+	r.appendASM(0x0a, "essence warp", `
+		ld a,$81
+		ld ($cc4b),a
+		jp $3e53`)
+	r.replaceASM(0x0a, 0x4745, "call essence warp",
+		"\xea\x4b\xcc", "call essence_warp")
 
 	// on left side of house, swap rafton 00 (builds raft) with rafton 01 (does
 	// trade sequence) if the player enters with the magic oar *and* global
 	// flag 26 (rafton has built raft) is not set.
-	setRaftonSubID := r.appendToBank(0x0a, "set rafton sub ID",
-		"\xcd\xf3\x31\xc2\x05\x3b\xfa\xc0\xc6\xfe\x09\xc2\x5b\x4d"+
-			"\x3e\x01\x12\xc3\xac\x4d")
-	r.replace(0x0a, 0x4d55, "jump set rafton sub ID",
-		"\xcd\xf3\x31", "\xc3"+setRaftonSubID)
+	r.appendASM(0x0a, "set rafton sub ID", `
+		call $31f3
+		jp nz,$3b05
+		ld a,($c6c0)
+		cp a,$09
+		jp nz,$4d5b
+		ld a,$01
+		ld (de),a
+		jp $4dac`)
+	r.replaceASM(0x0a, 0x4d55, "jump set rafton sub ID",
+		"\xcd\xf3\x31", "jp set_rafton_sub_id")
 
 	// bank 0b
 
 	// always get item from king zora before permission to enter jabu-jabu.
-	kingZoraCheck := r.appendToBank(0x0b, "king zora check",
-		"\xcd\xf3\x31\xc8\x3e\x10\xcd\x48\x17\x3e\x00\xd0\x3c\xc9")
-	r.replace(0x0b, 0x5464, "call king zora check",
-		"\xcd\xf3\x31", "\xcd"+kingZoraCheck)
+	r.appendASM(0x0b, "king zora check", `
+		call $31f3
+		ret z
+		ld a,$10
+		call $1748
+		ld a,$00
+		ret nc
+		inc a
+		ret`)
+	r.replaceASM(0x0b, 0x5464, "call king zora check",
+		"\xcd\xf3\x31", "call king_zora_check")
 
 	// fairy queen cutscene: just fade back in after the fairy leaves the
 	// screen, and play the long "puzzle solved" sound.
-	fairyQueenFunc := r.appendToBank(0x0b, "fairy queen func",
-		"\xcd\x99\x32\xaf\xea\x02\xcc\xea\x8a\xcc\x3e\x5b\xcd\x98\x0c"+
-			"\x3e\x30\xcd\xf9\x31\xc9")
-	r.replace(0x0b, 0x7954, "call fairy queen func",
-		"\xea\x04\xcc", "\xcd"+fairyQueenFunc)
+	r.appendASM(0x0b, "fairy queen func", `
+		call $3299
+		xor a
+		ld ($cc02),a
+		ld ($cc8a),a
+		ld a,$5b
+		call $0c98
+		ld a,$30
+		call $31f9
+		ret`)
+	r.replaceASM(0x0b, 0x7954, "call fairy queen func",
+		"\xea\x04\xcc", "call fairy_queen_func")
 
 	// bank 0c
 
@@ -462,42 +1053,66 @@ func initAgesEOB() {
 
 	// set room flags for other side of symmetry city bridge at end of building
 	// cutscene.
-	setBridgeFlag := r.appendToBank(0x15, "set bridge flag",
-		"\xe5\xaf\xea\x8a\xcc\x3e\x25\xcd\xf9\x31"+
-			"\x21\x24\xc7\xcb\xce\xe1\xc9")
+	setBridgeFlag := r.appendASM(0x15, "set bridge flag", `
+		push hl
+		xor a
+		ld ($cc8a),a
+		ld a,$25
+		call $31f9
+		ld hl,$c724
+		set 1,(hl)
+		pop hl
+		ret`)
 	r.replace(0x0c, 0x7a6f, "call set bridge flag",
 		"\xb9\xb6\x25", "\xe0"+setBridgeFlag)
 
 	// bank 0f
 
 	// set room flag for tunnel behind keep when defeating great moblin.
-	setTunnelFlag := r.appendToBank(0x0f, "set tunnel flag",
-		"\x21\x09\xc7\xcb\xc6\x21\xda\xca\xc9")
-	r.replace(0x0f, 0x7f3e, "call set tunnel flag",
-		"\x21\x09\xc7", "\xcd"+setTunnelFlag)
+	r.appendASM(0x0f, "set tunnel flag", `
+		ld hl,$c709
+		set 0,(hl)
+		ld hl,$cada
+		ret`)
+	r.replaceASM(0x0f, 0x7f3e, "call set tunnel flag",
+		"\x21\x09\xc7", "call set_tunnel_flag")
 
 	// bank 10
 
 	// keep black tower in initial state until the player got the item from the
 	// worker.
-	blackTowerCheck := r.appendToBank(0x10, "black tower check",
-		"\x21\x27\x79\xc8\xfa\xe1\xc9\xe6\x20\xc9")
-	r.replace(0x10, 0x7914, "call black tower check",
-		"\x21\x27\x79", "\xcd"+blackTowerCheck)
+	r.appendASM(0x10, "black tower check", `
+		ld hl,$7927
+		ret z
+		ld a,($c9e1)
+		and $20
+		ret`)
+	r.replaceASM(0x10, 0x7914, "call black tower check",
+		"\x21\x27\x79", "call black_tower_check")
 
 	// don't let echoes activate the special crescent island portal.
-	echoesPortalCheck := r.appendToBank(0x10, "echoes portal check",
-		"\xc5\x01\x00\xa9\xcd"+compareRoom+"\xc1\xfa\x8d\xcc\xc0\x3d\xc9")
-	r.replace(0x10, 0x7d88, "call echoes portal check",
-		"\xfa\x8d\xcc", "\xcd"+echoesPortalCheck)
+	r.appendASM(0x10, "echoes portal check", `
+		push bc
+		ld bc,$a900
+		call compare_room
+		pop bc
+		ld a,($cc8d)
+		ret nz
+		dec a
+		ret`)
+	r.replaceASM(0x10, 0x7d88, "call echoes portal check",
+		"\xfa\x8d\xcc", "call echoes_portal_check")
 
 	// bank 11
 
 	// allow collection of seeds with only shooter and no satchel
-	checkSeedHarvest := r.appendToBank(0x11, "check seed harvest",
-		"\xcd\x48\x17\xd8\x3e\x0f\xc3\x48\x17")
-	r.replace(0x11, 0x4aba, "call check seed harvest",
-		"\xcd\x48\x17", "\xcd"+checkSeedHarvest)
+	r.appendASM(0x11, "check seed harvest", `
+		call $1748
+		ret c
+		ld a,$0f
+		jp $1748`)
+	r.replaceASM(0x11, 0x4aba, "call check seed harvest",
+		"\xcd\x48\x17", "call check_seed_harvest")
 
 	// bank 12
 
@@ -518,17 +1133,30 @@ func initAgesEOB() {
 
 	// don't equip sword for shooting galleries if player don't have it
 	// (doesn't work anyway).
-	shootingGalleryEquip := r.appendToBank(0x15, "shooting gallery equip",
-		"\x3e\x05\xcd\x48\x17\x3e\x00\x22\xd0\x2b\x3e\x05\x22\xc9")
-	r.replace(0x15, 0x50ae, "call shooting gallery equip",
-		"\x3e\x05\x22", "\xcd"+shootingGalleryEquip)
+	r.appendASM(0x15, "shooting gallery equip", `
+		ld a,$05
+		call $1748
+		ld a,$00
+		ld (hl+),a
+		ret nc
+		dec hl
+		ld a,$05
+		ld (hl+),a
+		ret`)
+	r.replaceASM(0x15, 0x50ae, "call shooting gallery equip",
+		"\x3e\x05\x22", "call shooting_gallery_equip")
 
 	// always make "boomerang" second prize for target carts, checking room
 	// flag 6 to track it.
-	targetCartsItem := r.appendToBank(0x15, "target carts item",
-		"\xcd\x7d\x19\xcb\x77\x3e\x04\xca\xbb\x66\xcd\x3e\x04\xc3\xa5\x66")
-	r.replace(0x15, 0x66a2, "call target carts item",
-		"\xcd\x3e\x04", "\xc3"+targetCartsItem)
+	r.appendASM(0x15, "target carts item", `
+		call $197d
+		bit 6,a
+		ld a,$04
+		jp z,$66bb
+		call $043e
+		jp $66a5`)
+	r.replaceASM(0x15, 0x66a2, "call target carts item",
+		"\xcd\x3e\x04", "jp target_carts_item")
 	// set room flag 6 when "boomerang" is given in script.
 	targetCartsFlag := r.appendToBank(0x0c, "target carts flag",
 		"\xde\x06\x02\xb1\x40\xc1")
@@ -540,94 +1168,246 @@ func initAgesEOB() {
 	// given a treasure ID in dx42, return hl = the start of the treasure data
 	// + 1, accounting for progressive upgrades. also writes the new treasure
 	// ID to d070, which is used to set the treasure obtained flag.
-	upgradeTreasure := r.appendToBank(0x16, "upgrade treasure",
-		"\x1e\x42\x1a\x47\xcd"+getUpgradedTreasure+"\x1e\x70\x78\x12\xc9")
+	r.appendASM(0x16, "upgrade treasure", `
+		ld e,$42
+		ld a,(de)
+		ld b,a
+		call get_upgraded_treasure
+		ld e,$70
+		ld a,b
+		ld (de),a
+		ret`)
 
 	// just get item bc's sprite index in e.
-	getItemSpriteIndexBody := r.appendToBank(0x16, "get item sprite index body",
-		"\xcd"+getTreasureDataBody+"\x23\x23\x7e\x5f\xc9")
-	getItemSpriteIndex := r.appendToBank(0x00, "get item sprite index",
-		"\x1e\x16\x21"+getItemSpriteIndexBody+"\xc3\x8a\x00")
+	r.appendASM(0x16, "get item sprite index body", `
+		call get_treasure_data_body
+		inc hl
+		inc hl
+		ld a,(hl)
+		ld e,a
+		ret`)
+	r.appendASM(0x00, "get item sprite index", `
+		ld e,$16
+		ld hl,get_item_sprite_index_body
+		jp $008a`)
 
 	// return collection mode in a and e, based on current room. call is in
-	// bank 16, func is in bank 00, body is in bank 06.
-	collectModeTable := r.appendToBank(0x06, "collect mode table",
-		makeAgesCollectModeTable())
+	// bank 16, func is in bank 00, body is in bank 06. the body and its data
+	// are only reached through the func, so they go in an extra bank if the
+	// ROM is expanded.
+	collectBank := byte(0x06)
+	if ctx.expanded {
+		collectBank = firstExpandedBank
+	}
+	r.appendToBank(collectBank, "collect mode table",
+		ctx.makeAgesCollectModeTable())
 	// maku tree item falls or exists on floor depending on script position.
-	collectMakuTreeFunc := r.appendToBank(0x06, "collect maku tree",
-		"\xfa\x58\xd2\xfe\x84\x1e\x29\xc8\x1e\x0a\xc9")
+	collectMakuTreeFunc := r.appendASM(collectBank, "collect maku tree", `
+		ld a,($d258)
+		cp a,$84
+		ld e,$29
+		ret z
+		ld e,$0a
+		ret`)
 	// target carts items appear with a poof if they're in the enclosure.
-	collectTargetCartsFunc := r.appendToBank(0x06, "collect target carts",
-		"\x1e\x4d\x1a\xfe\x78\x1e\x19\xc8\x1e\x0a\xc9")
+	collectTargetCartsFunc := r.appendASM(collectBank, "collect target carts", `
+		ld e,$4d
+		ld a,(de)
+		cp a,$78
+		ld e,$19
+		ret z
+		ld e,$0a
+		ret`)
 	// big bang game items appear with a poof if they're above the goron.
-	collectBigBangFunc := r.appendToBank(0x06, "collect big bang game",
-		"\x1e\x4b\x1a\xfe\x38\x1e\x19\xc8\x1e\x0a\xc9")
+	collectBigBangFunc := r.appendASM(collectBank, "collect big bang game", `
+		ld e,$4b
+		ld a,(de)
+		cp a,$38
+		ld e,$19
+		ret z
+		ld e,$0a
+		ret`)
 	// lava juice trading goron also has a chest in the room.
-	collectLavaJuiceFunc := r.appendToBank(0x06, "collect lava juice room",
-		"\x1e\x4d\x1a\xfe\x68\x1e\x0a\xd8\x1e\x38\xc9")
-	collectModeJumpTable := r.appendToBank(0x06, "collect mode jump table",
+	collectLavaJuiceFunc := r.appendASM(collectBank,
+		"collect lava juice room", `
+		ld e,$4d
+		ld a,(de)
+		cp a,$68
+		ld e,$0a
+		ret c
+		ld e,$38
+		ret`)
+	r.appendToBank(collectBank, "collect mode jump table",
 		collectMakuTreeFunc+collectTargetCartsFunc+collectBigBangFunc+
 			collectLavaJuiceFunc)
-	collectModeLookupBody := r.appendToBank(0x06, "collect mode lookup body",
-		"\xfa\x2d\xcc\x47\xfa\x30\xcc\x4f\x1e\x01\x21"+collectModeTable+
-			"\xcd"+searchDoubleKey+"\x5f\xd0\x7e\x5f\xfe\x80\xd8"+
-			"\x21"+collectModeJumpTable+"\xe6\x7f\x87\xd7\x2a\x66\x6f\xe9")
-	collectModeLookup := r.appendToBank(0x00, "collect mode lookup",
-		"\xc5\xd5\xe5\x1e\x06\x21"+collectModeLookupBody+"\xcd\x8a\x00\x7b"+
-			"\xe1\xfe\xff\x20\x02\x2b\x2a\xd1\xc1\xc9")
+	r.appendASM(collectBank, "collect mode lookup body", `
+		ld a,($cc2d)
+		ld b,a
+		ld a,($cc30)
+		ld c,a
+		ld e,$01
+		ld hl,collect_mode_table
+		call search_by_double_key
+		ld e,a
+		ret nc
+		ld a,(hl)
+		ld e,a
+		cp a,$80
+		ret c
+		ld hl,collect_mode_jump_table
+		and $7f
+		add a,a
+		rst $10
+		ld a,(hl+)
+		ld h,(hl)
+		ld l,a
+		jp hl`)
+	r.appendASM(0x00, "collect mode lookup", fmt.Sprintf(`
+		push bc
+		push de
+		push hl
+		ld e,$%02x
+		ld hl,collect_mode_lookup_body
+		call $008a
+		ld a,e
+		pop hl
+		cp a,$ff
+		jr nz,next
+		dec hl
+		ld a,(hl+)
+	next:
+		pop de
+		pop bc
+		ret`, collectBank))
 	// return treasure data address and collect mode modified as necessary,
 	// given a treasure ID in dx42.
-	modifyTreasure := r.appendToBank(0x16, "modify treasure",
-		"\xcd"+upgradeTreasure+"\xcd"+collectModeLookup+"\x47\xcb\x37\xc9")
-	r.replace(0x16, 0x4539, "call modify treasure",
-		"\x47\xcb\x37", "\xcd"+modifyTreasure)
+	r.appendASM(0x16, "modify treasure", `
+		call upgrade_treasure
+		call collect_mode_lookup
+		ld b,a
+		swap a
+		ret`)
+	r.replaceASM(0x16, 0x4539, "call modify treasure",
+		"\x47\xcb\x37", "call modify_treasure")
 
 	// bank 3f
 
 	// set hl to the address of the item sprite with ID a.
-	calcItemSpriteAddr := r.appendToBank(0x3f, "get item sprite addr",
-		"\x21\xdb\x66\x5f\x87\xd7\x7b\xd7\xc9")
+	r.appendASM(0x3f, "get item sprite addr", `
+		ld hl,$66db
+		ld e,a
+		add a,a
+		rst $10
+		ld a,e
+		rst $10
+		ret`)
 	// set hl to the address of the item sprite for the item at hl in bank e.
-	lookupItemSpriteAddr := r.appendToBank(0x3f, "look up item sprite addr",
-		"\xc5\xcd"+readWord+"\xcd"+getItemSpriteIndex+
-			"\x7b\xcd"+calcItemSpriteAddr+"\xc1\xc9")
+	r.appendASM(0x3f, "look up item sprite addr", `
+		push bc
+		call read_word
+		call get_item_sprite_index
+		ld a,e
+		call get_item_sprite_addr
+		pop bc
+		ret`)
 
 	// copy three bytes at hl to a temporary ram address, and set hl to the
 	// address of the last byte, with a as the value.
-	copySpriteData := r.appendToBank(0x3f, "copy sprite data",
-		"\xd5\x11\xf0\xcf\x2a\x12\x13\x2a\x12\x13\x7e\x12"+
-			"\x62\x6b\xd1\xc9")
+	r.appendASM(0x3f, "copy sprite data", `
+		push de
+		ld de,$cff0
+		ld a,(hl+)
+		ld (de),a
+		inc de
+		ld a,(hl+)
+		ld (de),a
+		inc de
+		ld a,(hl)
+		ld (de),a
+		ld h,d
+		ld l,e
+		pop de
+		ret`)
 
 	// make the deku forest soldier that gives the item red instead of blue.
-	soldierSprite := r.appendToBank(0x3f, "soldier sprite", "\x4d\x00\x22")
-	setSoldierSprite := r.appendToBank(0x3f, "set soldier sprite",
-		"\x21"+soldierSprite+"\xf1\xc9")
+	r.appendToBank(0x3f, "soldier sprite", "\x4d\x00\x22")
+	setSoldierSprite := r.appendASM(0x3f, "set soldier sprite", `
+		ld hl,soldier_sprite
+		pop af
+		ret`)
 	// these interactions use the same flags as regular items
-	setShopItemSprite := r.appendToBank(0x3f, "set shop item sprite",
-		"\x1e\x09\x21\x11\x45\xcd"+lookupItemSpriteAddr+"\xf1\xc9")
-	setHiddenTokaySprite := r.appendToBank(0x3f, "set hidden tokay sprite",
-		"\x1e\x15\x21\x36\x5b\xcd"+lookupItemSpriteAddr+"\xf1\xc9")
-	setWildTokaySprite := r.appendToBank(0x3f, "set wild tokay sprite",
-		"\x1e\x15\x21\xbb\x5b\xcd"+lookupItemSpriteAddr+"\xf1\xc9")
+	setShopItemSprite := r.appendASM(0x3f, "set shop item sprite", `
+		ld e,$09
+		ld hl,$4511
+		call look_up_item_sprite_addr
+		pop af
+		ret`)
+	setHiddenTokaySprite := r.appendASM(0x3f, "set hidden tokay sprite", `
+		ld e,$15
+		ld hl,$5b36
+		call look_up_item_sprite_addr
+		pop af
+		ret`)
+	setWildTokaySprite := r.appendASM(0x3f, "set wild tokay sprite", `
+		ld e,$15
+		ld hl,$5bbb
+		call look_up_item_sprite_addr
+		pop af
+		ret`)
 	// interaction 6b, can't handle bomb flower and needs different flags
-	set6BSprite := r.appendToBank(0x3f, "set interaction 6b sprite",
-		"\xcd"+lookupItemSpriteAddr+"\xcd"+copySpriteData+
-			"\xcb\x46\x20\x03\x34\x18\x01\x35\x2b\x2b\xf1\xc9")
-	setInventionSprite := r.appendToBank(0x3f, "set invention sprite",
-		"\x1e\x0c\x21\x32\x72\xc3"+set6BSprite)
-	setChevalTestSprite := r.appendToBank(0x3f, "set cheval's test sprite",
-		"\x1e\x0c\x21\x3b\x72\xc3"+set6BSprite)
+	r.appendASM(0x3f, "set interaction 6b sprite", `
+		call look_up_item_sprite_addr
+		call copy_sprite_data
+		bit 0,(hl)
+		jr nz,next
+		inc (hl)
+		jr next2
+	next:
+		dec (hl)
+	next2:
+		dec hl
+		dec hl
+		pop af
+		ret`)
+	setInventionSprite := r.appendASM(0x3f, "set invention sprite", `
+		ld e,$0c
+		ld hl,$7232
+		jp set_interaction_6b_sprite`)
+	setChevalTestSprite := r.appendASM(0x3f, "set cheval's test sprite", `
+		ld e,$0c
+		ld hl,$723b
+		jp set_interaction_6b_sprite`)
 	// interaction 80, can't handle bomb flower and needs different flags
-	set80Sprite := r.appendToBank(0x3f, "set interaction 80 sprite",
-		"\xcd"+lookupItemSpriteAddr+"\xcd"+copySpriteData+
-			"\x5f\xe6\x0f\x20\x05\x7b\xc6\x03\x18\x06"+
-			"\xfe\x02\x7b\x28\x01\x3c\x77\x2b\x2b\xf1\xc9")
-	setLibraryPastSprite := r.appendToBank(0x3f, "set library past sprite",
-		"\x1e\x15\x21\xd8\x5d\xc3"+set80Sprite)
-	setLibrarySprite := r.appendToBank(0x3f, "set library sprite",
-		"\x1e\x15\x21\xb9\x5d\xc3"+set80Sprite)
+	r.appendASM(0x3f, "set interaction 80 sprite", `
+		call look_up_item_sprite_addr
+		call copy_sprite_data
+		ld e,a
+		and $0f
+		jr nz,next
+		ld a,e
+		add a,$03
+		jr next2
+	next:
+		cp a,$02
+		ld a,e
+		jr z,next2
+		inc a
+	next2:
+		ld (hl),a
+		dec hl
+		dec hl
+		pop af
+		ret`)
+	setLibraryPastSprite := r.appendASM(0x3f, "set library past sprite", `
+		ld e,$15
+		ld hl,$5dd8
+		jp set_interaction_80_sprite`)
+	setLibrarySprite := r.appendASM(0x3f, "set library sprite", `
+		ld e,$15
+		ld hl,$5db9
+		jp set_interaction_80_sprite`)
 	// table of ID, sub ID, jump address
-	customSpriteTable := r.appendToBank(0x3f, "custom sprite table",
+	r.appendToBank(0x3f, "custom sprite table",
 		"\x40\x00"+setSoldierSprite+
 			"\x47\x0d"+setShopItemSprite+ // 150 rupees only
 			"\x63\x14"+setHiddenTokaySprite+ // iron shield
@@ -639,18 +1419,42 @@ func initAgesEOB() {
 			"\x80\x08"+setLibrarySprite+
 			"\xff")
 	// override the sprites loaded for certain ID / sub ID pairs.
-	loadCustomSprite := r.appendToBank(0x3f, "load custom sprite",
-		"\xcd\x37\x44\xf5\xc5\xe5\x1e\x41\x1a\x47\x1c\x1a\x4f"+
-			"\x1e\x02\x21"+customSpriteTable+"\xcd"+searchDoubleKey+
-			"\x30\x08\x2a\x47\x7e\xe1\x67\x68\xc1\xe9\xe1\xc1\xf1\xc9")
-	r.replace(0x3f, 0x4356, "call load custom sprite",
-		"\xcd\x37\x44", "\xcd"+loadCustomSprite)
+	r.appendASM(0x3f, "load custom sprite", `
+		call $4437
+		push af
+		push bc
+		push hl
+		ld e,$41
+		ld a,(de)
+		ld b,a
+		inc e
+		ld a,(de)
+		ld c,a
+		ld e,$02
+		ld hl,custom_sprite_table
+		call search_by_double_key
+		jr nc,next
+		ld a,(hl+)
+		ld b,a
+		ld a,(hl)
+		pop hl
+		ld h,a
+		ld l,b
+		pop bc
+		jp hl
+	next:
+		pop hl
+		pop bc
+		pop af
+		ret`)
+	r.replaceASM(0x3f, 0x4356, "call load custom sprite",
+		"\xcd\x37\x44", "call load_custom_sprite")
 }
 
 // makes ages-specific additions to the collection mode table.
-func makeAgesCollectModeTable() string {
+func (ctx *Context) makeAgesCollectModeTable() string {
 	b := new(strings.Builder)
-	table := makeCollectModeTable()
+	table := ctx.makeCollectModeTable()
 	b.WriteString(table[:len(table)-1]) // strip final ff
 
 	// add eatern symmetry city brother
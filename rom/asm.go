@@ -0,0 +1,524 @@
+package rom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// this file is a small assembler for the game boy CPU, so that code patches
+// can be written as source instead of byte strings. the syntax is:
+//
+//	label:              ; labels end with a colon
+//	    ld a,(hl+)      ; one instruction per line, comments start with ;
+//	    cp a,$47        ; numbers are $hex, 0xhex, or decimal
+//	    jr nc,label     ; expressions can add or subtract labels and numbers
+//	    ldh ($b5),a     ; high page loads use ldh
+//	    db $02,$37,$17  ; data bytes
+//	    dw label+1      ; little-endian data words
+//
+// labels are bank-relative addresses, like the addresses returned by
+// appendToBank.
+
+// operand kinds
+const (
+	opReg8  = iota // b, c, d, e, h, l, (hl), a
+	opReg16        // bc, de, hl, sp, af
+	opIndBC        // (bc)
+	opIndDE        // (de)
+	opHLI          // (hl+)
+	opHLD          // (hl-)
+	opIndC         // (c)
+	opInd          // (expr)
+	opSPOff        // sp+expr
+	opImm          // expr
+)
+
+type operand struct {
+	kind int
+	reg  int    // register index, for opReg8 and opReg16
+	name string // register name, for opReg8 and opReg16
+	expr string // for opInd, opSPOff, and opImm
+	raw  string // the operand as written
+}
+
+var (
+	reg8Indexes = map[string]int{
+		"b": 0, "c": 1, "d": 2, "e": 3, "h": 4, "l": 5, "(hl)": 6, "a": 7,
+	}
+	reg16Indexes = map[string]int{
+		"bc": 0, "de": 1, "hl": 2, "sp": 3, "af": 3,
+	}
+	condIndexes = map[string]int{"nz": 0, "z": 1, "nc": 2, "c": 3}
+
+	// 8-bit arithmetic, in opcode order
+	aluOps = map[string]byte{
+		"add": 0, "adc": 1, "sub": 2, "sbc": 3,
+		"and": 4, "xor": 5, "or": 6, "cp": 7,
+	}
+
+	// cb-prefixed rotates and shifts, in opcode order
+	shiftOps = map[string]byte{
+		"rlc": 0, "rrc": 1, "rl": 2, "rr": 3,
+		"sla": 4, "sra": 5, "swap": 6, "srl": 7,
+	}
+
+	// cb-prefixed bit operations
+	bitOps = map[string]byte{"bit": 0x40, "res": 0x80, "set": 0xc0}
+
+	// instructions with no operands
+	impliedOps = map[string]byte{
+		"nop": 0x00, "rlca": 0x07, "rrca": 0x0f, "rla": 0x17, "rra": 0x1f,
+		"daa": 0x27, "cpl": 0x2f, "scf": 0x37, "ccf": 0x3f, "halt": 0x76,
+		"reti": 0xd9, "di": 0xf3, "ei": 0xfb,
+	}
+)
+
+func parseOperand(s string) operand {
+	op := parseOperandKind(s)
+	op.raw = s
+	return op
+}
+
+func parseOperandKind(s string) operand {
+	switch s {
+	case "(bc)":
+		return operand{kind: opIndBC}
+	case "(de)":
+		return operand{kind: opIndDE}
+	case "(hl+)", "(hli)":
+		return operand{kind: opHLI}
+	case "(hl-)", "(hld)":
+		return operand{kind: opHLD}
+	case "(c)", "($ff00+c)":
+		return operand{kind: opIndC}
+	}
+	if i, ok := reg8Indexes[s]; ok {
+		return operand{kind: opReg8, reg: i, name: s}
+	}
+	if i, ok := reg16Indexes[s]; ok {
+		return operand{kind: opReg16, reg: i, name: s}
+	}
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		return operand{kind: opInd, expr: s[1 : len(s)-1]}
+	}
+	if strings.HasPrefix(s, "sp+") || strings.HasPrefix(s, "sp-") {
+		return operand{kind: opSPOff, expr: s[2:]}
+	}
+	return operand{kind: opImm, expr: s}
+}
+
+// an assembler holds the state of one assembly.
+type assembler struct {
+	symbols map[string]uint16 // given symbols and labels
+	final   bool              // labels may be undefined until the last pass
+	pc      uint16            // address of the current instruction
+}
+
+// evaluates a sum of numbers and symbols, like "label+$10-2".
+func (as *assembler) eval(expr string) (int, error) {
+	if expr == "" {
+		return 0, fmt.Errorf("missing value")
+	}
+
+	total, sign := 0, 1
+	for expr != "" {
+		i := strings.IndexAny(expr[1:], "+-") + 1
+		if i == 0 {
+			i = len(expr)
+		}
+		term := expr[:i]
+		expr = expr[i:]
+
+		switch term[0] {
+		case '+':
+			sign, term = 1, term[1:]
+		case '-':
+			sign, term = -1, term[1:]
+		}
+
+		var v int64
+		var err error
+		switch {
+		case term == "":
+			return 0, fmt.Errorf("missing value")
+		case strings.HasPrefix(term, "$"):
+			v, err = strconv.ParseInt(term[1:], 16, 32)
+		case strings.HasPrefix(term, "0x"):
+			v, err = strconv.ParseInt(term[2:], 16, 32)
+		case term[0] >= '0' && term[0] <= '9':
+			v, err = strconv.ParseInt(term, 10, 32)
+		default:
+			addr, ok := as.symbols[term]
+			if !ok && as.final {
+				return 0, fmt.Errorf("undefined symbol %q", term)
+			}
+			v = int64(addr)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("bad number %q", term)
+		}
+		total += sign * int(v)
+	}
+
+	return total, nil
+}
+
+// evaluates an expression that must fit in a byte, signed or unsigned.
+func (as *assembler) evalByte(expr string) (byte, error) {
+	v, err := as.eval(expr)
+	if err != nil {
+		return 0, err
+	}
+	if as.final && (v < -0x80 || v > 0xff) {
+		return 0, fmt.Errorf("%s out of byte range", expr)
+	}
+	return byte(v), nil
+}
+
+// evaluates an expression that must fit in a word.
+func (as *assembler) evalWord(expr string) ([]byte, error) {
+	v, err := as.eval(expr)
+	if err != nil {
+		return nil, err
+	}
+	if as.final && (v < 0 || v > 0xffff) {
+		return nil, fmt.Errorf("%s out of word range", expr)
+	}
+	return []byte{byte(v), byte(v >> 8)}, nil
+}
+
+// evaluates a jr target as an offset from the end of a two-byte instruction.
+func (as *assembler) evalRelative(expr string) (byte, error) {
+	v, err := as.eval(expr)
+	if err != nil {
+		return 0, err
+	}
+	offset := v - int(as.pc) - 2
+	if as.final && (offset < -0x80 || offset > 0x7f) {
+		return 0, fmt.Errorf("jr target %s out of range", expr)
+	}
+	return byte(offset), nil
+}
+
+// returns the machine code for one instruction.
+func (as *assembler) encode(mnemonic string, args []string) ([]byte, error) {
+	ops := make([]operand, len(args))
+	for i, arg := range args {
+		ops[i] = parseOperand(arg)
+	}
+
+	// accept "cp a,b" as well as "cp b"
+	if _, ok := aluOps[mnemonic]; ok && len(ops) == 2 &&
+		ops[0].kind == opReg8 && ops[0].name == "a" {
+		ops = ops[1:]
+	}
+
+	if op, ok := impliedOps[mnemonic]; ok && len(ops) == 0 {
+		return []byte{op}, nil
+	}
+	if op, ok := aluOps[mnemonic]; ok && len(ops) == 1 {
+		if ops[0].kind == opReg8 {
+			return []byte{0x80 | op<<3 | byte(ops[0].reg)}, nil
+		}
+		if ops[0].kind == opImm {
+			n, err := as.evalByte(ops[0].expr)
+			return []byte{0xc6 | op<<3, n}, err
+		}
+	}
+	if op, ok := shiftOps[mnemonic]; ok && len(ops) == 1 &&
+		ops[0].kind == opReg8 {
+		return []byte{0xcb, op<<3 | byte(ops[0].reg)}, nil
+	}
+	if op, ok := bitOps[mnemonic]; ok && len(ops) == 2 &&
+		ops[0].kind == opImm && ops[1].kind == opReg8 {
+		bit, err := as.eval(ops[0].expr)
+		if err == nil && (bit < 0 || bit > 7) {
+			err = fmt.Errorf("bit %d out of range", bit)
+		}
+		return []byte{0xcb, op | byte(bit)<<3 | byte(ops[1].reg)}, err
+	}
+
+	switch mnemonic {
+	case "stop":
+		if len(ops) == 0 {
+			return []byte{0x10, 0x00}, nil
+		}
+	case "ld":
+		if len(ops) == 2 {
+			return as.encodeLoad(ops[0], ops[1])
+		}
+	case "ldh":
+		if len(ops) == 2 && ops[0].kind == opInd && ops[1].name == "a" {
+			n, err := as.evalByte(strings.TrimPrefix(ops[0].expr, "$ff00+"))
+			return []byte{0xe0, n}, err
+		}
+		if len(ops) == 2 && ops[0].name == "a" && ops[1].kind == opInd {
+			n, err := as.evalByte(strings.TrimPrefix(ops[1].expr, "$ff00+"))
+			return []byte{0xf0, n}, err
+		}
+	case "ldhl":
+		if len(ops) == 2 && ops[0].name == "sp" && ops[1].kind == opImm {
+			n, err := as.evalByte(ops[1].expr)
+			return []byte{0xf8, n}, err
+		}
+	case "inc", "dec":
+		if len(ops) == 1 && ops[0].kind == opReg8 {
+			op := byte(0x04)
+			if mnemonic == "dec" {
+				op = 0x05
+			}
+			return []byte{op | byte(ops[0].reg)<<3}, nil
+		}
+		if len(ops) == 1 && ops[0].kind == opReg16 && ops[0].name != "af" {
+			op := byte(0x03)
+			if mnemonic == "dec" {
+				op = 0x0b
+			}
+			return []byte{op | byte(ops[0].reg)<<4}, nil
+		}
+	case "add":
+		if len(ops) == 2 && ops[0].name == "hl" && ops[1].kind == opReg16 &&
+			ops[1].name != "af" {
+			return []byte{0x09 | byte(ops[1].reg)<<4}, nil
+		}
+		if len(ops) == 2 && ops[0].name == "sp" && ops[1].kind == opImm {
+			n, err := as.evalByte(ops[1].expr)
+			return []byte{0xe8, n}, err
+		}
+	case "push", "pop":
+		if len(ops) == 1 && ops[0].kind == opReg16 && ops[0].name != "sp" {
+			op := byte(0xc5)
+			if mnemonic == "pop" {
+				op = 0xc1
+			}
+			return []byte{op | byte(ops[0].reg)<<4}, nil
+		}
+	case "jp", "call", "jr":
+		return as.encodeJump(mnemonic, ops)
+	case "ret":
+		if len(ops) == 0 {
+			return []byte{0xc9}, nil
+		}
+		if cc, ok := condIndexes[ops[0].raw]; ok && len(ops) == 1 {
+			return []byte{0xc0 | byte(cc)<<3}, nil
+		}
+	case "rst":
+		if len(ops) == 1 && ops[0].kind == opImm {
+			v, err := as.eval(ops[0].expr)
+			if err == nil && (v&7 != 0 || v < 0 || v > 0x38) {
+				err = fmt.Errorf("bad rst vector %s", ops[0].expr)
+			}
+			return []byte{0xc7 | byte(v)}, err
+		}
+	}
+
+	return nil, fmt.Errorf("bad instruction: %s %s",
+		mnemonic, strings.Join(args, ","))
+}
+
+// returns the machine code for an ld instruction.
+func (as *assembler) encodeLoad(dst, src operand) ([]byte, error) {
+	switch {
+	case dst.kind == opReg8 && src.kind == opReg8:
+		if dst.reg == 6 && src.reg == 6 {
+			break // that's halt
+		}
+		return []byte{0x40 | byte(dst.reg)<<3 | byte(src.reg)}, nil
+	case dst.kind == opReg8 && src.kind == opImm:
+		n, err := as.evalByte(src.expr)
+		return []byte{0x06 | byte(dst.reg)<<3, n}, err
+	case dst.kind == opReg16 && dst.name != "af" && src.kind == opImm:
+		nn, err := as.evalWord(src.expr)
+		return append([]byte{0x01 | byte(dst.reg)<<4}, nn...), err
+	case dst.name == "sp" && src.name == "hl":
+		return []byte{0xf9}, nil
+	case dst.name == "hl" && src.kind == opSPOff:
+		n, err := as.evalByte(src.expr)
+		return []byte{0xf8, n}, err
+	case dst.kind == opInd && src.name == "sp":
+		nn, err := as.evalWord(dst.expr)
+		return append([]byte{0x08}, nn...), err
+	case dst.kind == opInd && src.name == "a":
+		nn, err := as.evalWord(dst.expr)
+		return append([]byte{0xea}, nn...), err
+	case dst.name == "a" && src.kind == opInd:
+		nn, err := as.evalWord(src.expr)
+		return append([]byte{0xfa}, nn...), err
+	case src.name == "a":
+		switch dst.kind {
+		case opIndBC:
+			return []byte{0x02}, nil
+		case opIndDE:
+			return []byte{0x12}, nil
+		case opHLI:
+			return []byte{0x22}, nil
+		case opHLD:
+			return []byte{0x32}, nil
+		case opIndC:
+			return []byte{0xe2}, nil
+		}
+	case dst.name == "a":
+		switch src.kind {
+		case opIndBC:
+			return []byte{0x0a}, nil
+		case opIndDE:
+			return []byte{0x1a}, nil
+		case opHLI:
+			return []byte{0x2a}, nil
+		case opHLD:
+			return []byte{0x3a}, nil
+		case opIndC:
+			return []byte{0xf2}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("bad operands for ld")
+}
+
+// returns the machine code for a jp, call, or jr instruction.
+func (as *assembler) encodeJump(mnemonic string, ops []operand) ([]byte, error) {
+	if mnemonic == "jp" && len(ops) == 1 &&
+		(ops[0].name == "hl" || ops[0].name == "(hl)") {
+		return []byte{0xe9}, nil
+	}
+
+	cond := -1
+	if len(ops) == 2 {
+		cc, ok := condIndexes[ops[0].raw]
+		if !ok {
+			return nil, fmt.Errorf("bad condition for %s", mnemonic)
+		}
+		cond, ops = cc, ops[1:]
+	}
+	if len(ops) != 1 || ops[0].kind != opImm {
+		return nil, fmt.Errorf("bad operands for %s", mnemonic)
+	}
+
+	var op byte
+	switch mnemonic {
+	case "jp":
+		op = 0xc3
+		if cond != -1 {
+			op = 0xc2 | byte(cond)<<3
+		}
+	case "call":
+		op = 0xcd
+		if cond != -1 {
+			op = 0xc4 | byte(cond)<<3
+		}
+	case "jr":
+		op = 0x18
+		if cond != -1 {
+			op = 0x20 | byte(cond)<<3
+		}
+		e, err := as.evalRelative(ops[0].expr)
+		return []byte{op, e}, err
+	}
+
+	nn, err := as.evalWord(ops[0].expr)
+	return append([]byte{op}, nn...), err
+}
+
+// returns the data for a db or dw directive.
+func (as *assembler) encodeData(directive string, args []string) ([]byte,
+	error) {
+	var data []byte
+	for _, arg := range args {
+		if directive == "db" {
+			n, err := as.evalByte(arg)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, n)
+		} else {
+			nn, err := as.evalWord(arg)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, nn...)
+		}
+	}
+	return data, nil
+}
+
+// assemble assembles game boy source code into machine code that starts at
+// the given bank offset. the source can use its own labels and the given
+// symbols as values.
+func assemble(org uint16, src string,
+	symbols map[string]uint16) (string, error) {
+	as := &assembler{symbols: make(map[string]uint16, len(symbols))}
+	for k, v := range symbols {
+		as.symbols[k] = v
+	}
+
+	// labels are defined on the first pass and used on the second, which is
+	// possible because instruction sizes don't depend on values.
+	var b []byte
+	for pass := 0; pass < 2; pass++ {
+		as.final = pass == 1
+		as.pc = org
+		b = b[:0]
+
+		for i, line := range strings.Split(src, "\n") {
+			if j := strings.IndexByte(line, ';'); j != -1 {
+				line = line[:j]
+			}
+			line = strings.ToLower(strings.TrimSpace(line))
+
+			if j := strings.IndexByte(line, ':'); j != -1 {
+				label := strings.TrimSpace(line[:j])
+				if !as.final {
+					if _, ok := as.symbols[label]; ok {
+						return "", fmt.Errorf("line %d: %s redefined",
+							i+1, label)
+					}
+					as.symbols[label] = as.pc
+				}
+				line = strings.TrimSpace(line[j+1:])
+			}
+			if line == "" {
+				continue
+			}
+
+			mnemonic, rest := line, ""
+			if j := strings.IndexAny(line, " \t"); j != -1 {
+				mnemonic, rest = line[:j], line[j+1:]
+			}
+			var args []string
+			if rest = strings.Replace(rest, " ", "", -1); rest != "" {
+				args = strings.Split(rest, ",")
+			}
+
+			var code []byte
+			var err error
+			if mnemonic == "db" || mnemonic == "dw" {
+				code, err = as.encodeData(mnemonic, args)
+			} else {
+				code, err = as.encode(mnemonic, args)
+			}
+			if err != nil {
+				return "", fmt.Errorf("line %d: %v", i+1, err)
+			}
+			b = append(b, code...)
+			as.pc += uint16(len(code))
+		}
+	}
+
+	return string(b), nil
+}
+
+// returns the name that code appended with the given name can be referred to
+// by in source, e.g. "dont_drown_link" for "don't drown link".
+func asmLabel(name string) string {
+	b := new(strings.Builder)
+	for _, c := range strings.ToLower(name) {
+		switch {
+		case c == ' ':
+			b.WriteRune('_')
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9'):
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package rom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAssemble(t *testing.T) {
+	for _, c := range []struct {
+		src, want string
+	}{
+		{"nop", "\x00"},
+		{"ld b,c", "\x41"},
+		{"ld (hl),$12", "\x36\x12"},
+		{"ld a,(hl+)", "\x2a"},
+		{"ld (hld),a", "\x32"},
+		{"ld hl,$c6b4", "\x21\xb4\xc6"},
+		{"ld ($c6b4),a", "\xea\xb4\xc6"},
+		{"ld a,(c)", "\xf2"},
+		{"ld hl,sp+2", "\xf8\x02"},
+		{"ldh ($b5),a", "\xe0\xb5"},
+		{"ldh a,($ff00+$b5)", "\xf0\xb5"},
+		{"add hl,de", "\x19"},
+		{"add a,b", "\x80"},
+		{"xor a", "\xaf"},
+		{"cp $47", "\xfe\x47"},
+		{"inc e", "\x1c"},
+		{"dec bc", "\x0b"},
+		{"push af", "\xf5"},
+		{"pop de", "\xd1"},
+		{"call $1717", "\xcd\x17\x17"},
+		{"call nz,$1717", "\xc4\x17\x17"},
+		{"jp c,$4000", "\xda\x00\x40"},
+		{"jp hl", "\xe9"},
+		{"ret", "\xc9"},
+		{"ret nc", "\xd0"},
+		{"rst $10", "\xd7"},
+		{"swap a", "\xcb\x37"},
+		{"bit 7,(hl)", "\xcb\x7e"},
+		{"set 0,c", "\xcb\xc1"},
+		{"db $02,$37,-1", "\x02\x37\xff"},
+		{"dw $3f89", "\x89\x3f"},
+		{"loop: jr loop", "\x18\xfe"},
+		{"jr z,end\nnop\nend:", "\x28\x01\x00"},
+		{"dw end+1 ; comment\nend:", "\x03\x40"},
+	} {
+		got, err := assemble(0x4000, c.src, nil)
+		if err != nil {
+			t.Errorf("%q: %v", c.src, err)
+		} else if got != c.want {
+			t.Errorf("%q: want % x, got % x", c.src, c.want, got)
+		}
+	}
+
+	for _, src := range []string{
+		"ld (hl),(hl)",
+		"jp nope,$4000",
+		"ld a,$100",
+		"call undefined",
+		"jr far\n" + strings.Repeat("nop\n", 0x80) + "far:",
+		"a:\na:",
+	} {
+		if _, err := assemble(0x4000, src, nil); err == nil {
+			t.Errorf("no error for %q", src)
+		}
+	}
+}
+
+func TestAssembledPatches(t *testing.T) {
+	ctx := NewContext(GameSeasons)
+
+	// these patches were byte strings before the assembler was added
+	for name, want := range map[string]string{
+		"no music func": "\x67\xfe\x47\x30\x03\x3e\x08\xc9\xf0\xb5\xc9",
+		"no music call": "\xcd\xc8\x3e",
+		"rod cutscene gfx func": "\x1e\x41\x1a\xfe\xe6\xc0\x1c\x1a\xfe\x02" +
+			"\x28\x03\x1d\x1a\xc9\x3e\x60\xc9",
+		"rod cutscene gfx call": "\xcd\xd3\x3e",
+		"progressive item func": "\xd5\x5f\xcd\xf5\x3e\x7b\xd1\xd0\xfe\x05" +
+			"\x20\x04\x21\x17\x3f\xc9\xfe\x06\x20\x04\x21\x1a\x3f\xc9" +
+			"\xfe\x13\x20\x04\x21\x1d\x3f\xc9\xfe\x17\x20\x04\x21\x20" +
+			"\x3f\xc9\xfe\x19\xc0\x21\x23\x3f\xc9",
+		"warning func": "\xc5\xd5\xcd\x10\x7a\xd1\xc1\xc9\xfa\x4e\xcc\x47" +
+			"\xfa\xb0\xc6\x4f\xfa\x4c\xcc\xfe\x46\xca\xf9\x79\xfe\x7c" +
+			"\xca\xa0\x79\xfe\x6e\xca\xaf\x79\xfe\x3d\xca\xc2\x79\xfe" +
+			"\x5c\xca\xd1\x79\xfe\x78\xca\xe4\x79\xc3\x7b\x79",
+	} {
+		got := string(ctx.codeMutables[name].(*MutableRange).New)
+		if got != want {
+			t.Errorf("%s: want % x, got % x", name, want, got)
+		}
+	}
+}
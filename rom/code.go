@@ -5,68 +5,149 @@ import (
 	"strings"
 )
 
-// this file is for mutables that go at the end of banks. each should be a
-// self-contained unit (i.e. don't jr to anywhere outside the byte string) so
-// that they can be appended automatically with respect to their size.
+// this file is for mutables that go in the free space of banks. each should be
+// a self-contained unit (i.e. don't jr to anywhere outside the byte string) so
+// that they can be placed automatically with respect to their size.
 
 // return e.g. "\x2d\x79" for 0x792d
 func addrString(addr uint16) string {
 	return string([]byte{byte(addr), byte(addr >> 8)})
 }
 
-// adds code at the given address, returning the length of the byte string.
-func addCode(name string, bank byte, offset uint16, code string) uint16 {
-	codeMutables[name] = MutableString(Addr{bank, offset},
-		string([]byte{bank}), code)
-	return uint16(len(code))
+// a freeRegion is unused space in a bank, from start up to end.
+type freeRegion struct {
+	bank       byte
+	start, end uint16
 }
 
 type romBanks struct {
-	endOfBank []uint16
+	free     [][]freeRegion     // by bank
+	used     []int              // bytes allocated, by bank
+	labels   map[string]uint16  // see asmLabel
+	mutables map[string]Mutable // code and data added or replaced
+	err      error              // first allocation that failed
 }
 
-var codeMutables = map[string]Mutable{}
+func newRomBanks(free []freeRegion) *romBanks {
+	r := &romBanks{
+		free:     make([][]freeRegion, 0x80), // room for expanded banks
+		used:     make([]int, 0x80),
+		labels:   make(map[string]uint16),
+		mutables: make(map[string]Mutable),
+	}
+	for _, fr := range free {
+		r.free[fr.bank] = append(r.free[fr.bank], fr)
+	}
+	return r
+}
 
-// appendToBank appends the given data to the end of the given bank, associates
-// it with the given name, and returns the address of the data as a string such
-// as "\xc8\x3e" for 0x3ec8. it panics if the end of the bank is zero or if the
-// data would overflow the bank.
-func (r *romBanks) appendToBank(bank byte, name, data string) string {
-	eob := r.endOfBank[bank]
+// allocates size bytes in the first free region of the bank that fits them,
+// returning the address of the space.
+func (r *romBanks) alloc(bank byte, size int) (uint16, bool) {
+	for i, fr := range r.free[bank] {
+		if int(fr.end)-int(fr.start) >= size {
+			r.free[bank][i].start += uint16(size)
+			r.used[bank] += size
+			return fr.start, true
+		}
+	}
+	return 0, false
+}
 
-	if eob == 0 {
-		panic(fmt.Sprintf("end of bank %02x undefined for %s", bank, name))
+// appendToBank appends the given data to free space in the given bank,
+// associates it with the given name, and returns the address of the data as a
+// string such as "\xc8\x3e" for 0x3ec8. if the data doesn't fit, the error is
+// returned by Mutate.
+func (r *romBanks) appendToBank(bank byte, name, data string) string {
+	addr, ok := r.alloc(bank, len(data))
+	if !ok {
+		if r.err == nil {
+			r.err = fmt.Errorf("not enough space for %s in bank %02x",
+				name, bank)
+		}
+		// keep the name and label so that lookups don't fail before Mutate
+		r.mutables[name] = MutableString(Addr{bank, 0}, "", data)
+		r.labels[asmLabel(name)] = 0
+		return addrString(0)
 	}
 
-	if eob+uint16(len(data)) > 0x8000 {
-		panic(fmt.Sprintf("not enough space for %s in bank %02x", name, bank))
+	r.mutables[name] = MutableString(Addr{bank, addr}, "", data)
+	r.labels[asmLabel(name)] = addr
+
+	return addrString(addr)
+}
+
+// appendASM acts as appendToBank, but assembles the given source first. the
+// source can refer to earlier appended code by label; see asmLabel.
+func (r *romBanks) appendASM(bank byte, name, src string) string {
+	// instruction sizes don't depend on addresses, so the size is known
+	// before the address is.
+	size := len(r.assemble(0, name, src))
+	for _, fr := range r.free[bank] {
+		if int(fr.end)-int(fr.start) >= size {
+			return r.appendToBank(bank, name, r.assemble(fr.start, name, src))
+		}
 	}
+	return r.appendToBank(bank, name, r.assemble(0, name, src))
+}
 
-	codeMutables[name] = MutableString(Addr{bank, eob}, "", data)
-	r.endOfBank[bank] += uint16(len(data))
+// A BankUsage is the amount of free space used and left in a bank.
+type BankUsage struct {
+	Bank       byte
+	Used, Free int
+}
 
-	return addrString(eob)
+// SpaceUsage returns the free space used and left in each bank that has any.
+// It also returns an error if any code didn't fit.
+func (ctx *Context) SpaceUsage() ([]BankUsage, error) {
+	usage := make([]BankUsage, 0)
+	for bank, free := range ctx.banks.free {
+		if len(free) == 0 {
+			continue
+		}
+		bu := BankUsage{Bank: byte(bank), Used: ctx.banks.used[bank]}
+		for _, fr := range free {
+			bu.Free += int(fr.end) - int(fr.start)
+		}
+		usage = append(usage, bu)
+	}
+	return usage, ctx.banks.err
 }
 
 // replace replaces the old data at the given address with the new data, and
 // associates the change with the given name. actual replacement will fail at
 // runtime if the old data does not match the original data in the ROM.
 func (r *romBanks) replace(bank byte, offset uint16, name, old, new string) {
-	codeMutables[name] = MutableString(Addr{bank, offset}, old, new)
+	r.mutables[name] = MutableString(Addr{bank, offset}, old, new)
+}
+
+// replaceASM acts as replace, but assembles the new source first.
+func (r *romBanks) replaceASM(bank byte, offset uint16, name, old,
+	src string) {
+	r.replace(bank, offset, name, old, r.assemble(offset, name, src))
+}
+
+// assembles source for the given address, panicking on error.
+func (r *romBanks) assemble(offset uint16, name, src string) string {
+	code, err := assemble(offset, src, r.labels)
+	if err != nil {
+		panic(fmt.Sprintf("assembling %s: %v", name, err))
+	}
+	return code
 }
 
 // replaceMultiple acts as replace, but operates on multiple addresses.
 func (r *romBanks) replaceMultiple(addrs []Addr, name, old, new string) {
-	codeMutables[name] = MutableStrings(addrs, old, new)
+	r.mutables[name] = MutableStrings(addrs, old, new)
 }
 
 // returns a byte table of (group, room, collect mode) entries for randomized
 // items. in ages, a mode >7f means to use &7f as an index to a jump table for
 // special cases.
-func makeCollectModeTable() string {
+func (ctx *Context) makeCollectModeTable() string {
 	b := new(strings.Builder)
 
-	for _, slot := range ItemSlots {
+	for _, slot := range ctx.ItemSlots {
 		// trees and slots where it doesn't matter (shops, rod)
 		if slot.collectMode == 0 {
 			continue
@@ -0,0 +1,92 @@
+package rom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRomBanks(t *testing.T) {
+	ctx := NewContext(GameAges)
+	r := newRomBanks([]freeRegion{
+		{0x02, 0x7ff0, 0x8000},
+		{0x02, 0x7000, 0x7004},
+		{0x03, 0x7f00, 0x8000},
+	})
+	ctx.banks, ctx.codeMutables = r, r.mutables
+
+	four := "\x00\x01\x02\x03"
+	if addr := r.appendToBank(0x02, "a", four); addr != "\xf0\x7f" {
+		t.Errorf("want 7ff0, got % x", addr)
+	}
+	if addr := r.appendToBank(0x02, "b", four); addr != "\xf4\x7f" {
+		t.Errorf("want 7ff4, got % x", addr)
+	}
+	if addr := r.appendToBank(0x02, "c", four+four); addr != "\xf8\x7f" {
+		t.Errorf("want 7ff8, got % x", addr)
+	}
+	// the first region is full now, so the second is used
+	if addr := r.appendToBank(0x02, "d", "\x00\x01"); addr != "\x00\x70" {
+		t.Errorf("want 7000, got % x", addr)
+	}
+	if addr := r.appendToBank(0x03, "e", four); addr != "\x00\x7f" {
+		t.Errorf("want 7f00, got % x", addr)
+	}
+
+	usage, err := ctx.SpaceUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []BankUsage{{0x02, 18, 2}, {0x03, 4, 0xfc}}
+	if len(usage) != len(want) {
+		t.Fatalf("want %v, got %v", want, usage)
+	}
+	for i := range want {
+		if usage[i] != want[i] {
+			t.Errorf("want %v, got %v", want[i], usage[i])
+		}
+	}
+
+	// running out of space is an error, not a panic
+	r.appendToBank(0x02, "f", four)
+	r.appendASM(0x02, "g", "call f")
+	if _, err := ctx.SpaceUsage(); err == nil {
+		t.Error("no error for full bank")
+	}
+	if _, _, err := ctx.Mutate(make([]byte, 0x100000), ""); err == nil {
+		t.Error("no error from Mutate for full bank")
+	}
+}
+
+func TestExpandedROM(t *testing.T) {
+	ctx := NewExpandedContext(GameAges)
+	body := ctx.codeMutables["collect mode lookup body"].(*MutableRange)
+	if body.Addrs[0].bank != firstExpandedBank {
+		t.Fatalf("want bank %02x, got %02x",
+			firstExpandedBank, body.Addrs[0].bank)
+	}
+
+	// this only has to not check past the end of the data
+	vanilla := make([]byte, 0x100000)
+	ctx.Verify(vanilla)
+
+	b, _, err := ctx.Mutate(vanilla, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != expandedSize {
+		t.Fatalf("want %x bytes, got %x", expandedSize, len(b))
+	}
+	if b[0x148] != expandedSizeCode {
+		t.Errorf("want ROM size %02x, got %02x", expandedSizeCode, b[0x148])
+	}
+	offset := body.Addrs[0].fullOffset()
+	if string(b[offset:offset+len(body.New)]) != string(body.New) {
+		t.Errorf("expanded bank code not written")
+	}
+
+	// the call into the body has to switch to its bank
+	lookup := ctx.codeMutables["collect mode lookup"].(*MutableRange)
+	if !strings.Contains(string(lookup.New), "\x1e\x40") {
+		t.Errorf("lookup doesn't switch to bank 40: % x", lookup.New)
+	}
+}
@@ -0,0 +1,126 @@
+package rom
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SetD0KeyShuffle adds the hero's cave small key chest to the item slots if
+// enabled is true. This has no effect in ages, which has no small key chest
+// slots.
+func (ctx *Context) SetD0KeyShuffle(enabled bool) {
+	ctx.d0KeyShuffled = enabled && len(ctx.keySlots) > 0
+	if !ctx.d0KeyShuffled {
+		return
+	}
+
+	for name, slot := range ctx.keySlots {
+		slot.Treasure = ctx.Treasures[slot.treasureName]
+		ctx.ItemSlots[name] = slot
+	}
+}
+
+// IsSmallKey returns true iff the named treasure is a dungeon-specific small
+// key.
+func (ctx *Context) IsSmallKey(name string) bool {
+	t := ctx.Treasures[name]
+	return t != nil && t.id == 0x30 && t.param&0x80 != 0
+}
+
+// returns the names of the small key chest slots in the order that they
+// appear in the collection mode table.
+func (ctx *Context) orderedKeySlotNames() []string {
+	names := make([]string, 0, len(ctx.keySlots))
+	for name := range ctx.keySlots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// read the rooms of small key chests from the ROM's chest data, and fill in
+// their entries in the collection mode table.
+func (ctx *Context) setKeyChestRooms(b []byte) {
+	mut := ctx.codeMutables["collection mode table"].(*MutableRange)
+	i := len(mut.New) - 1 - len(ctx.keySlots)*3
+
+	for _, name := range ctx.orderedKeySlotNames() {
+		slot := ctx.keySlots[name]
+		slot.room = b[slot.idAddrs[0].fullOffset()-1] // chest entry: yx, room
+
+		mut.New[i] = slot.group
+		mut.New[i+1] = slot.room
+		mut.New[i+2] = slot.collectMode
+		i += 3
+	}
+}
+
+// names of the custom sub ID tables for dungeon-specific items, in order of
+// treasure ID starting at 30.
+var dungeonItemTables = []string{"small key treasure table",
+	"boss key treasure table", "compass treasure table",
+	"dungeon map treasure table"}
+
+// point the small key, boss key, compass, and map treasures to sub ID tables
+// that include the dungeon-specific items, copying the vanilla sub IDs into
+// them.
+func (ctx *Context) setDungeonItemTreasureData(b []byte) error {
+	for i, name := range dungeonItemTables {
+		id := 0x30 + i
+		addr := Addr{0x15, uint16(0x5129 + id*4)}
+		entry := addr.fullOffset()
+		if b[entry]&0x80 == 0 {
+			return fmt.Errorf("treasure %02x data has no sub ID table", id)
+		}
+		old := &Addr{0x15, uint16(b[entry+1]) | uint16(b[entry+2])<<8}
+
+		mut := ctx.codeMutables[name].(*MutableRange)
+		copy(mut.New[:4*4], b[old.fullOffset():old.fullOffset()+4*4])
+
+		newAddr := mut.Addrs[0].offset
+		b[entry+1], b[entry+2] = byte(newAddr), byte(newAddr>>8)
+	}
+
+	return nil
+}
+
+// SetBossKeysAnywhere makes boss keys give themselves to their own dungeons
+// instead of the current one, so that they can be placed anywhere. This is
+// only supported in seasons.
+func (ctx *Context) SetBossKeysAnywhere(enabled bool) {
+	if !enabled || ctx.codeMutables["boss key treasure table"] == nil {
+		return
+	}
+
+	for i := byte(1); i <= 8; i++ {
+		ctx.Treasures[fmt.Sprintf("d%d boss key", i)] =
+			seasonsDungeonItem(0x31, i)
+	}
+	for _, slot := range ctx.ItemSlots {
+		slot.Treasure = ctx.Treasures[slot.treasureName]
+	}
+}
+
+// SetStartingBossKeys gives the player the boss keys for all dungeons at the
+// start of the game if enabled is true.
+func (ctx *Context) SetStartingBossKeys(enabled bool) {
+	mut := ctx.codeMutables["starting boss keys"].(*MutableRange)
+	if enabled {
+		mut.New = []byte{0xff, 0xff}
+	} else {
+		mut.New = []byte{0x00, 0x00}
+	}
+}
+
+// checks a slot against the treasure it holds in the vanilla ROM. key chests
+// and boss key slots hold dungeon-specific items in the randomizer.
+func (ctx *Context) checkVanillaSlot(b []byte, name string,
+	slot *MutableSlot) error {
+	vanilla := *slot
+	if ctx.keySlots[name] == slot {
+		vanilla.Treasure = ctx.vanillaTreasures["small key"]
+	} else {
+		vanilla.Treasure = ctx.vanillaTreasures[slot.treasureName]
+	}
+	return vanilla.Check(b)
+}
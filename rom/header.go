@@ -0,0 +1,96 @@
+package rom
+
+import (
+	"bytes"
+	"crypto/sha1"
+)
+
+// randomized ROMs are stamped with the randomizer's version at the end of the
+// last bank, which is free space in both games.
+const (
+	stampBank   = 0x3f
+	stampOffset = 0x7fe0
+	stampPrefix = "oracles-randomizer "
+)
+
+// writes the randomizer stamp, then fixes the header and global checksums.
+// this has to be the last change to the ROM data. returns the SHA-1 sum of the
+// data.
+func finishROM(b []byte, version string) []byte {
+	stamp := make([]byte, 0x8000-stampOffset)
+	copy(stamp, stampPrefix+version)
+	addr := Addr{stampBank, stampOffset}
+	copy(b[addr.fullOffset():], stamp)
+
+	setChecksums(b)
+	sum := sha1.Sum(b)
+	return sum[:]
+}
+
+// sets the cartridge header checksum at 0x14d and the global checksum at
+// 0x14e-0x14f to match the ROM data.
+func setChecksums(b []byte) {
+	var header byte
+	for _, v := range b[0x134:0x14d] {
+		header = header - v - 1
+	}
+	b[0x14d] = header
+
+	var global uint16
+	for i, v := range b {
+		if i != 0x14e && i != 0x14f {
+			global += uint16(v)
+		}
+	}
+	b[0x14e], b[0x14f] = byte(global>>8), byte(global)
+}
+
+// StampedVersion returns the version of the randomizer that randomized the
+// ROM data, or an empty string if the ROM wasn't randomized or is from a
+// version that didn't stamp ROMs.
+func StampedVersion(b []byte) string {
+	addr := Addr{stampBank, stampOffset}
+	offset := addr.fullOffset()
+	if len(b) < offset+0x8000-stampOffset {
+		return ""
+	}
+	stamp := b[offset : offset+0x8000-stampOffset]
+	if !bytes.HasPrefix(stamp, []byte(stampPrefix)) {
+		return ""
+	}
+	stamp = stamp[len(stampPrefix):]
+	if i := bytes.IndexByte(stamp, 0); i != -1 {
+		stamp = stamp[:i]
+	}
+	return string(stamp)
+}
+
+// expanded ROMs are twice the size of the vanilla ones, and the extra banks
+// are free space. the MBC5 that both games use can address them as is, so only
+// the ROM size in the header changes.
+const (
+	expandedSize     = 0x200000
+	expandedSizeCode = 0x06 // 2 MiB
+)
+
+// the first of the extra banks in an expanded ROM.
+const firstExpandedBank = 0x40
+
+// adds the extra banks of an expanded ROM to the free space.
+func (r *romBanks) addExpandedBanks() {
+	for bank := firstExpandedBank; bank < len(r.free); bank++ {
+		r.free[bank] = []freeRegion{{byte(bank), 0x4000, 0x8000}}
+	}
+}
+
+// returns a copy of the ROM data with empty banks added at the end and the
+// header changed to match.
+func expandROM(b []byte) []byte {
+	if len(b) >= expandedSize {
+		return b
+	}
+	e := make([]byte, expandedSize)
+	copy(e, b)
+	e[0x148] = expandedSizeCode
+	return e
+}
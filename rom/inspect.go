@@ -0,0 +1,205 @@
+package rom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// An Inspection is the randomized data read back out of a ROM.
+type Inspection struct {
+	Items     map[string]string // slot -> item, including seed trees
+	Seasons   map[string]byte   // area -> season ID (seasons only)
+	Companion int               // 1 to 3, or 0 if unknown
+	Version   string            // randomizer version, if stamped
+
+	// descriptions of data that doesn't match anything the randomizer would
+	// write.
+	Unknown []string
+}
+
+// Inspect reads the item placements and other randomized data from the ROM
+// data. The context should be new, and for the game of the ROM.
+func (ctx *Context) Inspect(b []byte) *Inspection {
+	ins := &Inspection{
+		Items:   make(map[string]string),
+		Version: StampedVersion(b),
+	}
+
+	addr := ctx.varMutables["animal region"].(*MutableRange).Addrs[0]
+	if companion := int(b[addr.fullOffset()]) - 0x0a; companion >= 1 &&
+		companion <= 3 {
+		ins.Companion = companion
+	} else {
+		ins.Unknown = append(ins.Unknown, fmt.Sprintf(
+			"animal region: unknown value %02x", b[addr.fullOffset()]))
+	}
+
+	// some slots' item IDs are in new code, and small key chests are only
+	// slots if their keys are shuffled, which shows in their contents.
+	ctx.setCodeSlotAddrs()
+	slots := make(map[string]*MutableSlot,
+		len(ctx.ItemSlots)+len(ctx.keySlots))
+	for name, slot := range ctx.ItemSlots {
+		slots[name] = slot
+	}
+	for name, slot := range ctx.keySlots {
+		slots[name] = slot
+	}
+	slotNames := make([]string, 0, len(slots))
+	for name := range slots {
+		slotNames = append(slotNames, name)
+	}
+	sort.Strings(slotNames)
+
+	r := ctx.newTreasureReader(ins.Companion)
+	for _, name := range slotNames {
+		slot := slots[name]
+		var item string
+		var err error
+		if strings.HasSuffix(slot.treasureName, " tree seeds") {
+			item, err = ctx.inspectSeedTree(b, name)
+		} else if len(slot.idAddrs) > 0 {
+			item, err = r.read(b, slot)
+		} else {
+			continue // dummy slot
+		}
+
+		if err != nil {
+			ins.Unknown = append(ins.Unknown, fmt.Sprintf("%s: %v", name, err))
+		} else if ctx.keySlots[name] == nil || item != "small key" {
+			ins.Items[name] = item
+		}
+	}
+
+	if ctx.game == GameSeasons {
+		ins.Seasons = make(map[string]byte, len(ctx.Seasons))
+		for name, mut := range ctx.Seasons {
+			ins.Seasons[strings.TrimSuffix(name, " season")] =
+				b[mut.Addrs[0].fullOffset()]
+		}
+	}
+
+	return ins
+}
+
+// identifies treasures by their IDs and sub IDs.
+type treasureReader struct {
+	vanilla   map[string]*Treasure
+	names     map[[2]byte][]string
+	used      map[[2]byte]int
+	companion int
+}
+
+func (ctx *Context) newTreasureReader(companion int) *treasureReader {
+	r := &treasureReader{
+		vanilla:   ctx.vanillaTreasures,
+		names:     make(map[[2]byte][]string),
+		used:      make(map[[2]byte]int),
+		companion: companion,
+	}
+
+	add := func(name string, t *Treasure) {
+		key := [2]byte{t.id, t.subID}
+		r.names[key] = append(r.names[key], name)
+	}
+	for name, t := range ctx.vanillaTreasures {
+		// seed tree "treasures" aren't real, and share IDs with real ones
+		if !strings.HasSuffix(name, " tree seeds") {
+			add(name, t)
+		}
+	}
+	if ctx.game == GameSeasons {
+		for i := byte(1); i <= 8; i++ {
+			add(fmt.Sprintf("d%d boss key", i), seasonsDungeonItem(0x31, i))
+		}
+	}
+	for _, names := range r.names {
+		sort.Strings(names)
+	}
+
+	return r
+}
+
+// returns the name of the item in a slot, based on the item IDs in the ROM
+// and the treasure data they refer to.
+func (r *treasureReader) read(b []byte, slot *MutableSlot) (string, error) {
+	id, err := readSameByte(b, slot.idAddrs)
+	if err != nil {
+		return "", fmt.Errorf("item IDs %v", err)
+	}
+	subID := byte(0)
+	if len(slot.subIDAddrs) > 0 {
+		if subID, err = readSameByte(b, slot.subIDAddrs); err != nil {
+			return "", fmt.Errorf("item sub IDs %v", err)
+		}
+	} else if t := r.vanilla[slot.treasureName]; t != nil {
+		subID = t.subID
+	}
+
+	key := [2]byte{id, subID}
+	names := r.names[key]
+	if len(names) == 0 {
+		return "", fmt.Errorf("unknown treasure %02x %02x", id, subID)
+	}
+
+	switch id {
+	case 0x0e:
+		// every flute is the flute for the seed's companion
+		if r.companion != 0 {
+			return []string{"ricky's flute", "dimitri's flute",
+				"moosh's flute"}[r.companion-1], nil
+		}
+	case 0x2d:
+		// rings all use the same item ID, so the ring is determined by the
+		// parameter in the treasure data.
+		t := r.vanilla[names[0]]
+		param := b[t.addr.fullOffset()+1]
+		if int(param) >= len(ringNames) {
+			return "", fmt.Errorf("unknown ring %02x", param)
+		}
+		return ringNames[param], nil
+	}
+
+	// if there's a generic name like "boss key", use it. otherwise, items
+	// with the same data are numbered, like "sword 1" and "sword 2", so hand
+	// the names out in order.
+	for _, name := range names {
+		if !strings.ContainsAny(name, "0123456789") {
+			return name, nil
+		}
+	}
+	i := r.used[key]
+	r.used[key]++
+	if i >= len(names) {
+		i = len(names) - 1
+	}
+	return names[i], nil
+}
+
+// returns the type of seeds on a seed tree, based on its map icon.
+func (ctx *Context) inspectSeedTree(b []byte, name string) (string, error) {
+	mut, ok := ctx.varMutables[name+" map icon"].(*MutableRange)
+	if !ok {
+		return "", fmt.Errorf("no map icon")
+	}
+	id := b[mut.Addrs[0].fullOffset()] - 0x15
+	for treasureName, t := range ctx.vanillaTreasures {
+		if t.id == id && strings.HasSuffix(treasureName, " tree seeds") {
+			return treasureName, nil
+		}
+	}
+	return "", fmt.Errorf("unknown map icon %02x", id+0x15)
+}
+
+// returns the byte at the given addresses, or an error if they differ.
+func readSameByte(b []byte, addrs []Addr) (byte, error) {
+	v := b[addrs[0].fullOffset()]
+	for _, addr := range addrs[1:] {
+		if b[addr.fullOffset()] != v {
+			return 0, fmt.Errorf("differ: %02x and %02x",
+				v, b[addr.fullOffset()])
+		}
+	}
+	return v, nil
+}
@@ -14,7 +14,8 @@ type MutableSlot struct {
 	paramAddrs, textAddrs    []Addr
 	gfxAddrs                 []Addr
 	group, room, collectMode byte
-	mapCoords                byte // overworld map coords, yx
+	mapCoords                byte     // overworld map coords, yx
+	ctx                      *Context // for treasure names and graphics
 }
 
 // Mutate replaces the given IDs, subIDs, and other applicable data in the ROM.
@@ -32,7 +33,7 @@ func (ms *MutableSlot) Mutate(b []byte) error {
 		b[addr.fullOffset()] = ms.Treasure.text
 	}
 	for _, addr := range ms.gfxAddrs {
-		gfx := itemGfx[FindTreasureName(ms.Treasure)]
+		gfx := ms.ctx.itemGfx[ms.ctx.FindTreasureName(ms.Treasure)]
 		for i := 0; i < 3; i++ {
 			b[addr.fullOffset()+i] = byte(gfx >> (8 * uint(2-i)))
 		}
@@ -73,7 +74,7 @@ func (ms *MutableSlot) Check(b []byte) error {
 		}
 	}
 	for _, addr := range ms.gfxAddrs {
-		gfx := itemGfx[FindTreasureName(ms.Treasure)]
+		gfx := ms.ctx.itemGfx[ms.ctx.FindTreasureName(ms.Treasure)]
 		for i := uint16(0); i < 3; i++ {
 			addr := Addr{addr.bank, addr.offset + i}
 			if err := check(b, addr, byte(gfx>>(8*(2-i)))); err != nil {
@@ -103,5 +104,3 @@ func basicSlot(treasure string, bank byte, idOffset, subIDOffset uint16,
 		mapCoords:    coords,
 	}
 }
-
-var ItemSlots map[string]*MutableSlot
@@ -84,76 +84,66 @@ func (mr *MutableRange) Check(b []byte) error {
 }
 
 // SetMusic sets music on or off in the modified ROM.
-func SetMusic(music bool) {
+func (ctx *Context) SetMusic(music bool) {
 	if music {
-		mut := codeMutables["no music call"].(*MutableRange)
+		mut := ctx.codeMutables["no music call"].(*MutableRange)
 		mut.New = mut.Old
 	}
 }
 
 // SetTreewarp sets treewarp on or off in the modified ROM.
-func SetTreewarp(treewarp bool) {
+func (ctx *Context) SetTreewarp(treewarp bool) {
 	if !treewarp {
-		mut := codeMutables["tree warp jump"].(*MutableRange)
+		mut := ctx.codeMutables["tree warp jump"].(*MutableRange)
 		mut.New = mut.Old
 	}
 }
 
 // SetAnimal sets the flute type and Natzu region type based on a companion
 // number 1 to 3.
-func SetAnimal(companion int) {
-	varMutables["animal region"].(*MutableRange).New =
+func (ctx *Context) SetAnimal(companion int) {
+	ctx.varMutables["animal region"].(*MutableRange).New =
 		[]byte{byte(companion + 0x0a)}
 
 	// ages
-	if varMutables["flute palette"] != nil {
-		mut := varMutables["flute palette"].(*MutableRange)
+	if ctx.varMutables["flute palette"] != nil {
+		mut := ctx.varMutables["flute palette"].(*MutableRange)
 		mut.New[0] = byte(0x10*(4-companion) + 3)
 	}
 }
 
 // SetTunicColor sets Link's tunic color (green, blue, red, or gold; value from 0-3)
-func SetTunicColor(color int) {
+func (ctx *Context) SetTunicColor(color int) {
 	for i := 0; i <= 9; i++ { // Object palettes
-		var mut = varMutables["object tunic color " + fmt.Sprint(i)].(*MutableRange)
+		var mut = ctx.varMutables["object tunic color "+fmt.Sprint(i)].(*MutableRange)
 		mut.New[0] = mut.Old[0] | byte(color)
 	}
 	for i := 0; i <= 21; i++ { // File select sprites
-		var mut = varMutables["file tunic color " + fmt.Sprint(i)].(*MutableRange)
+		var mut = ctx.varMutables["file tunic color "+fmt.Sprint(i)].(*MutableRange)
 		mut.New[0] = mut.Old[0] | byte(color)
 	}
 }
 
-// these mutables have fixed addresses and don't reference other mutables. try
-// to generally order them by address, unless a grouping between mutables in
-// different banks makes more sense.
-var fixedMutables map[string]Mutable
-
-// like the item slots, these are (usually) no-ops until the randomizer touches
-// them. these are also fixed, but generally need to have their values set
-// elsewhere in order to do anything.
-var varMutables map[string]Mutable
-
 // get a collated map of all mutables
-func getAllMutables() map[string]Mutable {
+func (ctx *Context) getAllMutables() map[string]Mutable {
 	slotMutables := make(map[string]Mutable)
 	treasureMutables := make(map[string]Mutable)
-	for k, v := range ItemSlots {
+	for k, v := range ctx.ItemSlots {
 		if v.Treasure == nil {
 			log.Fatalf("treasure named %s for %s is nil", v.treasureName, k)
 		}
 		if v.Treasure.addr.offset != 0 {
-			treasureMutables[FindTreasureName(v.Treasure)] = v.Treasure
+			treasureMutables[ctx.FindTreasureName(v.Treasure)] = v.Treasure
 		}
 		slotMutables[k] = v
 	}
 
 	mutableSets := []map[string]Mutable{
-		fixedMutables,
+		ctx.fixedMutables,
 		treasureMutables,
 		slotMutables,
-		varMutables,
-		codeMutables,
+		ctx.varMutables,
+		ctx.codeMutables,
 	}
 
 	// initialize master map w/ adequate capacity
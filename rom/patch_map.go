@@ -0,0 +1,153 @@
+package rom
+
+import (
+	"fmt"
+	"sort"
+)
+
+// a byteRange is the ROM offsets from start up to end.
+type byteRange struct {
+	start, end int
+}
+
+// returns the ranges of ROM data that a mutable writes.
+func mutableRanges(m Mutable) []byteRange {
+	ranges := make([]byteRange, 0)
+	add := func(addrs []Addr, size int) {
+		for _, addr := range addrs {
+			offset := addr.fullOffset()
+			ranges = append(ranges, byteRange{offset, offset + size})
+		}
+	}
+
+	switch m := m.(type) {
+	case *MutableRange:
+		add(m.Addrs, len(m.New))
+	case *MutableSlot:
+		// the slot's treasure data is a separate mutable
+		add(m.idAddrs, 1)
+		add(m.subIDAddrs, 1)
+		add(m.paramAddrs, 1)
+		add(m.textAddrs, 1)
+		add(m.gfxAddrs, 3)
+	case *Treasure:
+		if m.addr.offset != 0 {
+			add([]Addr{m.addr}, 4)
+		}
+	}
+
+	return ranges
+}
+
+// returns an error for each byte that more than one mutable range writes.
+// item slots are left out, since their IDs are often inside code that the
+// randomizer adds, and so are treasures, since items with the same data share
+// it.
+func (ctx *Context) checkOverlap() []error {
+	errors := make([]error, 0)
+	owners := make(map[int]string)
+
+	mutables := ctx.getAllMutables()
+	for _, name := range orderedKeys(mutables) {
+		m := mutables[name]
+		if _, ok := m.(*MutableRange); !ok {
+			continue
+		}
+		for _, r := range mutableRanges(m) {
+			for i := r.start; i < r.end; i++ {
+				if other, ok := owners[i]; ok {
+					errors = append(errors, fmt.Errorf(
+						"%s collides with %s at %x", name, other, i))
+				}
+				owners[i] = name
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// A PatchRange is a run of bytes that randomization changed.
+type PatchRange struct {
+	Owner    string // name of the mutable that changed it, or "" if none
+	Offset   int
+	Old, New []byte
+}
+
+// Location returns the bank and address of the range, like "15:792d".
+func (pr PatchRange) Location() string {
+	bank, addr := pr.Offset/bankSize, pr.Offset%bankSize
+	if bank > 0 {
+		addr += bankSize
+	}
+	return fmt.Sprintf("%02x:%04x", bank, addr)
+}
+
+// PatchMap compares vanilla ROM data to the data returned by Mutate, and
+// returns each range of changed bytes, sorted by owner and then offset. Where
+// mutables overlap, bytes belong to the smaller one.
+func (ctx *Context) PatchMap(vanilla, b []byte) []PatchRange {
+	type claim struct {
+		name string
+		r    byteRange
+	}
+	claims := []claim{
+		{"header", byteRange{0x134, 0x150}},
+		{"version stamp", byteRange{(&Addr{stampBank, stampOffset}).fullOffset(),
+			(&Addr{stampBank, 0x8000}).fullOffset()}},
+	}
+	mutables := ctx.getAllMutables()
+	for _, name := range orderedKeys(mutables) {
+		for _, r := range mutableRanges(mutables[name]) {
+			claims = append(claims, claim{name, r})
+		}
+	}
+	sort.SliceStable(claims, func(i, j int) bool {
+		return claims[i].r.end-claims[i].r.start >
+			claims[j].r.end-claims[j].r.start
+	})
+
+	// owners are indexes into claims, plus one so that zero is no owner
+	owners := make([]int, len(b))
+	for i, c := range claims {
+		for j := c.r.start; j < c.r.end && j < len(b); j++ {
+			owners[j] = i + 1
+		}
+	}
+
+	// expanded banks start out empty
+	old := func(i int) byte {
+		if i < len(vanilla) {
+			return vanilla[i]
+		}
+		return 0
+	}
+
+	ranges := make([]PatchRange, 0)
+	for i := 0; i < len(b); i++ {
+		if b[i] == old(i) {
+			continue
+		}
+		start, owner := i, owners[i]
+		for i < len(b) && b[i] != old(i) && owners[i] == owner {
+			i++
+		}
+		pr := PatchRange{Offset: start, New: b[start:i]}
+		for j := start; j < i; j++ {
+			pr.Old = append(pr.Old, old(j))
+		}
+		if owner != 0 {
+			pr.Owner = claims[owner-1].name
+		}
+		ranges = append(ranges, pr)
+		i-- // the loop increments past the byte that ended the range
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].Owner < ranges[j].Owner
+	})
+	return ranges
+}
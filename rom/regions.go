@@ -0,0 +1,52 @@
+package rom
+
+import "fmt"
+
+// regions of the game releases. only the US releases are supported; the
+// others are detected so that they can be rejected with a clear error.
+const (
+	RegionUS = iota
+	RegionEU
+	RegionJP
+)
+
+var regionNames = []string{"US", "EU", "JP"}
+
+// GetRegion returns the region of the ROM data, based on the destination code
+// and the last letter of the game code in the cartridge header.
+func GetRegion(b []byte) int {
+	if b[0x14a] == 0 {
+		return RegionJP
+	}
+	if b[0x142] == 'P' {
+		return RegionEU
+	}
+	return RegionUS
+}
+
+// RegionName returns the short name of a region, like "EU".
+func RegionName(region int) string {
+	if region < 0 || region >= len(regionNames) {
+		return "unknown"
+	}
+	return regionNames[region]
+}
+
+// SHA-1 sums of the vanilla US ROMs for each game.
+var vanillaSums = map[int]string{
+	GameAges: "\x88\x03\x74\xfb\x97\x8b\x18\xaf\x4a\xa5\x29\xe2\xe3\x2f" +
+		"\x7f\xfb\x4d\x7d\xd2\xf4",
+	GameSeasons: "\xba\x12\x68\x29\x0f\xb2\xb1\xb7\x05\x05\xd2\xd7\xb5\x82" +
+		"\x5f\xc8\xa4\x81\x6a\x4b",
+}
+
+// CheckRegion returns an error if ROMs of the given region aren't supported.
+// All addresses in this package are for the US releases, and the EU and JP
+// releases move too much code and text to share them.
+func CheckRegion(region int) error {
+	if region != RegionUS {
+		return fmt.Errorf("%s ROMs aren't supported yet; only US is",
+			RegionName(region))
+	}
+	return nil
+}
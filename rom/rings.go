@@ -0,0 +1,91 @@
+package rom
+
+import "fmt"
+
+// ring names, in order of ring ID. these are the same in both games.
+var ringNames = []string{
+	"friendship ring", "power ring L-1", "power ring L-2", "power ring L-3",
+	"armor ring L-1", "armor ring L-2", "armor ring L-3", "red ring",
+	"blue ring", "green ring", "cursed ring", "expert's ring",
+	"rang ring L-1", "blast ring", "gba time ring", "maple's ring",
+	"steadfast ring", "pegasus ring", "toss ring", "heart ring L-1",
+	"heart ring L-2", "swimmer's ring", "charge ring", "light ring L-1",
+	"light ring L-2", "bomber's ring", "green luck ring", "blue luck ring",
+	"gold luck ring", "red luck ring", "green holy ring", "blue holy ring",
+	"red holy ring", "snowshoe ring", "roc's ring", "quicksand ring",
+	"red joy ring", "blue joy ring", "gold joy ring", "green joy ring",
+	"discovery ring", "rang ring L-2", "octo ring", "moblin ring",
+	"like-like ring", "subrosian ring", "first gen ring", "spin ring",
+	"bombproof ring", "energy ring", "dbl. edged ring", "gba nature ring",
+	"slayer's ring", "rupee ring", "victory ring", "sign ring",
+	"100th ring", "whisp ring", "gasha ring", "peace ring",
+	"zora ring", "fist ring", "whimsical ring", "protection ring",
+}
+
+// RingNames returns the names of all rings, in order of ring ID.
+func RingNames() []string {
+	names := make([]string, len(ringNames))
+	copy(names, ringNames)
+	return names
+}
+
+// IsRing returns true iff the named treasure is a ring.
+func (ctx *Context) IsRing(name string) bool {
+	t := ctx.Treasures[name]
+	return t != nil && t.id == 0x2d
+}
+
+// add placeholder treasures for rings that aren't in any chest in the vanilla
+// game, so that they can be referred to by name. they have no data of their
+// own until SetRings gives them some.
+func (ctx *Context) addRingTreasures() {
+	for id, name := range ringNames {
+		if ctx.Treasures[name] == nil {
+			ctx.Treasures[name] = &Treasure{id: 0x2d, param: byte(id),
+				mode: collectChest, text: 0x54, sprite: 0x0e}
+		}
+	}
+}
+
+// SetRings changes which rings the vanilla ring treasures give. The map is of
+// ring names to the names of the vanilla ring treasures whose data they use.
+// It returns an error if any of the names aren't rings.
+func (ctx *Context) SetRings(rings map[string]string) error {
+	// start over from vanilla data, so that every ring is assigned fresh
+	ctx.addRingTreasures()
+	for name, t := range ctx.vanillaTreasures {
+		if t.id == 0x2d {
+			ctx.Treasures[name] = t
+		}
+	}
+
+	// read all the vanilla treasures before replacing any, since a ring can
+	// use the data of another ring that is itself being replaced.
+	treasures := make(map[string]*Treasure, len(rings))
+	for ring, vanilla := range rings {
+		id := ringID(ring)
+		t := ctx.vanillaTreasures[vanilla]
+		if id < 0 || t == nil || t.id != 0x2d {
+			return fmt.Errorf("can't replace %s with %s", vanilla, ring)
+		}
+
+		treasure := *t
+		treasure.param = byte(id)
+		treasures[ring] = &treasure
+	}
+	for ring, t := range treasures {
+		ctx.Treasures[ring] = t
+	}
+
+	return nil
+}
+
+// returns the ring ID of the named ring, or -1 if it isn't a ring.
+func ringID(name string) int {
+	for id, ringName := range ringNames {
+		if ringName == name {
+			return id
+		}
+	}
+	return -1
+}
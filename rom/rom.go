@@ -1,6 +1,6 @@
 // Package rom deals with the structure of the oracles ROM files themselves.
-// The given addresses are for the English versions of the games, and if two
-// are specified, Ages comes first.
+// The given addresses are for the US versions of the games, and if two are
+// specified, Ages comes first.
 package rom
 
 import (
@@ -18,75 +18,163 @@ const (
 	GameSeasons
 )
 
-var itemGfx map[string]int
+// A Context holds the data for one game that randomization
+// changes: item slots, treasures, and other mutables. Each randomized ROM
+// needs its own Context, since the Set functions and Mutate change it, but
+// separate contexts can be used at the same time.
+type Context struct {
+	ItemSlots map[string]*MutableSlot
+	Treasures map[string]*Treasure
+	Seasons   map[string]*MutableRange // default seasons (seasons only)
+
+	game             int
+	vanillaTreasures map[string]*Treasure    // unmodified treasures
+	keySlots         map[string]*MutableSlot // small key chests
+	d0KeyShuffled    bool                    // whether key chests are slots
+
+	// fixed mutables have fixed addresses and don't reference other
+	// mutables. var mutables are also fixed, but like the item slots, they're
+	// (usually) no-ops until the randomizer sets their values. code mutables
+	// are added to free space in banks, or replace code that calls them.
+	fixedMutables map[string]Mutable
+	varMutables   map[string]Mutable
+	codeMutables  map[string]Mutable
+	banks         *romBanks
+	expanded      bool // whether Mutate expands the ROM
+	itemGfx       map[string]int
+}
+
+// NewContext returns a context with vanilla data for the given game.
+func NewContext(game int) *Context {
+	return newContext(game, false)
+}
 
-func Init(game int) {
+// NewExpandedContext acts as NewContext, but Mutate expands the ROM to 2 MiB,
+// and code that can go in any bank is put in the extra banks.
+func NewExpandedContext(game int) *Context {
+	return newContext(game, true)
+}
+
+func newContext(game int, expanded bool) *Context {
+	ctx := &Context{game: game, expanded: expanded}
+
+	var vanillaTreasures map[string]*Treasure
+	var itemGfx map[string]int
 	if game == GameAges {
-		ItemSlots = agesSlots
-		Treasures = agesTreasures
-		fixedMutables = agesFixedMutables
-		varMutables = agesVarMutables
+		ctx.ItemSlots = newAgesSlots()
+		ctx.keySlots = map[string]*MutableSlot{}
+		vanillaTreasures = agesTreasures
+		ctx.fixedMutables = newAgesFixedMutables()
+		ctx.varMutables = newAgesVarMutables()
 		itemGfx = agesItemGfx
-		initAgesEOB()
 	} else {
-		ItemSlots = seasonsSlots
-		Treasures = seasonsTreasures
-		fixedMutables = seasonsFixedMutables
-		varMutables = seasonsVarMutables
+		ctx.ItemSlots = newSeasonsSlots()
+		ctx.keySlots = newSeasonsKeySlots()
+		vanillaTreasures = seasonsTreasures
+		ctx.fixedMutables = newSeasonsFixedMutables()
+		ctx.varMutables = newSeasonsVarMutables()
 		itemGfx = seasonsItemGfx
-		initSeasonsEOB()
 
-		for k, v := range Seasons {
-			varMutables[k] = v
+		ctx.Seasons = newDefaultSeasons()
+		for k, v := range ctx.Seasons {
+			ctx.varMutables[k] = v
 		}
 	}
 
-	for _, slot := range ItemSlots {
-		slot.Treasure = Treasures[slot.treasureName]
+	// the vanilla tables are shared by all contexts, so copy the treasures and
+	// the graphics, which get entries for more items below.
+	ctx.vanillaTreasures = make(map[string]*Treasure, len(vanillaTreasures))
+	for k, v := range vanillaTreasures {
+		t := *v
+		ctx.vanillaTreasures[k] = &t
+	}
+	ctx.itemGfx = make(map[string]int, len(itemGfx))
+	for k, v := range itemGfx {
+		ctx.itemGfx[k] = v
+	}
+
+	// copy the treasure map so that boss keys can be made dungeon-specific
+	ctx.Treasures = make(map[string]*Treasure, len(ctx.vanillaTreasures))
+	for k, v := range ctx.vanillaTreasures {
+		ctx.Treasures[k] = v
+	}
+	ctx.addRingTreasures()
+
+	if game == GameAges {
+		ctx.initAgesEOB()
+	} else {
+		ctx.initSeasonsEOB()
+	}
+	ctx.codeMutables = ctx.banks.mutables
+
+	for _, slots := range []map[string]*MutableSlot{ctx.ItemSlots,
+		ctx.keySlots} {
+		for _, slot := range slots {
+			slot.ctx = ctx
+		}
+	}
+	for _, slot := range ctx.ItemSlots {
+		slot.Treasure = ctx.Treasures[slot.treasureName]
 	}
 
-	// rings and boss keys all have the same sprite
-	for name, treasure := range Treasures {
+	// rings, keys, maps, and compasses of each kind have the same sprite
+	for name, treasure := range ctx.Treasures {
 		if treasure.id == 0x2d {
-			itemGfx[name] = itemGfx["ring"]
+			ctx.itemGfx[name] = ctx.itemGfx["ring"]
+		}
+		if treasure.id == 0x30 {
+			ctx.itemGfx[name] = ctx.itemGfx["small key"]
 		}
 		if treasure.id == 0x31 {
-			itemGfx[name] = itemGfx["boss key"]
+			ctx.itemGfx[name] = ctx.itemGfx["boss key"]
+		}
+		if treasure.id == 0x32 {
+			ctx.itemGfx[name] = ctx.itemGfx["compass"]
+		}
+		if treasure.id == 0x33 {
+			ctx.itemGfx[name] = ctx.itemGfx["dungeon map"]
 		}
 	}
 
 	// use these graphics as default for progressive items (seasons)
-	itemGfx["sword 1"] = itemGfx["sword L-1"]
-	itemGfx["sword 2"] = itemGfx["sword L-1"]
-	itemGfx["boomerang 1"] = itemGfx["boomerang L-1"]
-	itemGfx["boomerang 2"] = itemGfx["boomerang L-1"]
-	itemGfx["slingshot 1"] = itemGfx["slingshot L-1"]
-	itemGfx["slingshot 2"] = itemGfx["slingshot L-1"]
-	itemGfx["feather 1"] = itemGfx["feather L-1"]
-	itemGfx["feather 2"] = itemGfx["feather L-1"]
+	ctx.itemGfx["sword 1"] = ctx.itemGfx["sword L-1"]
+	ctx.itemGfx["sword 2"] = ctx.itemGfx["sword L-1"]
+	ctx.itemGfx["boomerang 1"] = ctx.itemGfx["boomerang L-1"]
+	ctx.itemGfx["boomerang 2"] = ctx.itemGfx["boomerang L-1"]
+	ctx.itemGfx["slingshot 1"] = ctx.itemGfx["slingshot L-1"]
+	ctx.itemGfx["slingshot 2"] = ctx.itemGfx["slingshot L-1"]
+	ctx.itemGfx["feather 1"] = ctx.itemGfx["feather L-1"]
+	ctx.itemGfx["feather 2"] = ctx.itemGfx["feather L-1"]
 
 	// (ages)
-	itemGfx["sword 1"] = itemGfx["sword L-1"]
-	itemGfx["switch hook 1"] = itemGfx["switch hook"]
-	itemGfx["switch hook 2"] = itemGfx["long hook"]
-	itemGfx["bracelet 1"] = itemGfx["bracelet"]
-	itemGfx["bracelet 2"] = itemGfx["power glove"]
-	itemGfx["harp 1"] = itemGfx["tune of echoes"]
-	itemGfx["harp 2"] = itemGfx["tune of currents"]
-	itemGfx["harp 3"] = itemGfx["tune of ages"]
-	itemGfx["flippers 1"] = itemGfx["flippers"]
-	itemGfx["flippers 2"] = itemGfx["mermaid suit"]
+	ctx.itemGfx["sword 1"] = ctx.itemGfx["sword L-1"]
+	ctx.itemGfx["switch hook 1"] = ctx.itemGfx["switch hook"]
+	ctx.itemGfx["switch hook 2"] = ctx.itemGfx["long hook"]
+	ctx.itemGfx["bracelet 1"] = ctx.itemGfx["bracelet"]
+	ctx.itemGfx["bracelet 2"] = ctx.itemGfx["power glove"]
+	ctx.itemGfx["harp 1"] = ctx.itemGfx["tune of echoes"]
+	ctx.itemGfx["harp 2"] = ctx.itemGfx["tune of currents"]
+	ctx.itemGfx["harp 3"] = ctx.itemGfx["tune of ages"]
+	ctx.itemGfx["flippers 1"] = ctx.itemGfx["flippers"]
+	ctx.itemGfx["flippers 2"] = ctx.itemGfx["mermaid suit"]
 
 	// get set of unique items (to determine which can be slotted freely)
 	treasureCounts := make(map[string]int)
-	for _, slot := range ItemSlots {
-		name := FindTreasureName(slot.Treasure)
+	for _, slot := range ctx.ItemSlots {
+		name := ctx.FindTreasureName(slot.Treasure)
 		if treasureCounts[name] == 0 {
 			treasureCounts[name] = 1
 		} else {
 			treasureCounts[name]++
 		}
 	}
+
+	return ctx
+}
+
+// Game returns the game that the context is for.
+func (ctx *Context) Game() int {
+	return ctx.game
 }
 
 // Addr is a fully-specified memory address.
@@ -114,19 +202,19 @@ func IsSeasons(b []byte) bool {
 }
 
 func IsUS(b []byte) bool {
-	return b[0x014a] != 0
+	return GetRegion(b) == RegionUS
 }
 
+// IsVanilla returns true if the ROM data matches the US release of either
+// game.
 func IsVanilla(b []byte) bool {
-	knownSum := "\x88\x03\x74\xfb\x97\x8b\x18\xaf\x4a\xa5\x29\xe2\xe3\x2f\x7f" +
-		"\xfb\x4d\x7d\xd2\xf4"
+	game := GameAges
 	if IsSeasons(b) {
-		knownSum = "\xba\x12\x68\x29\x0f\xb2\xb1\xb7\x05\x05\xd2\xd7\xb5\x82" +
-			"\x5f\xc8\xa4\x81\x6a\x4b"
+		game = GameSeasons
 	}
 	sum := sha1.Sum(b)
 
-	return string(sum[:]) == knownSum
+	return string(sum[:]) == vanillaSums[game]
 }
 
 // get mutables in order, so that sums are consistent with the same seed
@@ -139,72 +227,78 @@ func orderedKeys(m map[string]Mutable) []string {
 	return keys
 }
 
-// Mutate changes the contents of loaded ROM bytes in place. It returns a
-// checksum of the result or an error.
-func Mutate(b []byte, game int) ([]byte, error) {
-	if game == GameSeasons {
-		varMutables["initial season"].(*MutableRange).New =
-			[]byte{0x2d, Seasons["north horon season"].New[0]}
-		codeMutables["season after pirate cutscene"].(*MutableRange).New =
-			[]byte{Seasons["western coast season"].New[0]}
-
-		setTreasureMapData()
-
-		// explicitly set these addresses and IDs after their functions
-		codeAddr := codeMutables["star ore id func"].(*MutableRange).Addrs[0]
-		ItemSlots["subrosia seaside"].idAddrs[0].offset = codeAddr.offset + 2
-		ItemSlots["subrosia seaside"].subIDAddrs[0].offset = codeAddr.offset + 5
-		codeAddr = codeMutables["hard ore id func"].(*MutableRange).Addrs[0]
-		ItemSlots["great furnace"].idAddrs[0].offset = codeAddr.offset + 2
-		ItemSlots["great furnace"].subIDAddrs[0].offset = codeAddr.offset + 5
-		codeAddr = codeMutables["diver fake id script"].(*MutableRange).Addrs[0]
-		ItemSlots["master diver's reward"].idAddrs[0].offset = codeAddr.offset + 1
-		ItemSlots["master diver's reward"].subIDAddrs[0].offset = codeAddr.offset + 2
-	} else {
-		// explicitly set these addresses and IDs after their functions
-		mut := codeMutables["soldier script give item"].(*MutableRange)
-		slot := ItemSlots["deku forest soldier"]
-		slot.idAddrs[0].offset = mut.Addrs[0].offset + 13
-		slot.subIDAddrs[0].offset = mut.Addrs[0].offset + 14
-		codeAddr := codeMutables["target carts flag"].(*MutableRange).Addrs[0]
-		ItemSlots["target carts 2"].idAddrs[1].offset = codeAddr.offset + 1
-		ItemSlots["target carts 2"].subIDAddrs[1].offset = codeAddr.offset + 2
+// Mutate changes the contents of loaded ROM bytes, stamping them with the
+// given randomizer version. The bytes are changed in place unless the ROM is
+// expanded (see NewExpandedContext). It returns the new ROM data and its
+// checksum, or an error.
+func (ctx *Context) Mutate(b []byte, version string) ([]byte, []byte, error) {
+	if _, err := ctx.SpaceUsage(); err != nil {
+		return nil, nil, err
+	}
+	if ctx.expanded {
+		b = expandROM(b)
+	}
+
+	if ctx.game == GameSeasons {
+		ctx.varMutables["initial season"].(*MutableRange).New =
+			[]byte{0x2d, ctx.Seasons["north horon season"].New[0]}
+		ctx.codeMutables["season after pirate cutscene"].(*MutableRange).New =
+			[]byte{ctx.Seasons["western coast season"].New[0]}
+
+		ctx.setTreasureMapData()
+
+		if ctx.d0KeyShuffled {
+			ctx.setKeyChestRooms(b)
+		}
+		if err := ctx.setDungeonItemTreasureData(b); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	setSeedData(game)
+	ctx.setCodeSlotAddrs()
+	ctx.setSeedData()
+
+	// addresses are final now, so make sure nothing writes over anything else
+	if errs := ctx.checkOverlap(); errs != nil {
+		return nil, nil, errs[0]
+	}
 
 	var err error
-	mutables := getAllMutables()
+	mutables := ctx.getAllMutables()
 	for _, k := range orderedKeys(mutables) {
 		err = mutables[k].Mutate(b)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	// explicitly set these IDs after their functions are written
-	if game == GameSeasons {
-		ItemSlots["subrosia seaside"].Mutate(b)
-		ItemSlots["great furnace"].Mutate(b)
-		ItemSlots["master diver's reward"].Mutate(b)
+	if ctx.game == GameSeasons {
+		ctx.ItemSlots["subrosia seaside"].Mutate(b)
+		ctx.ItemSlots["great furnace"].Mutate(b)
+		ctx.ItemSlots["master diver's reward"].Mutate(b)
 	} else {
-		ItemSlots["nayru's house"].Mutate(b)
-		ItemSlots["deku forest soldier"].Mutate(b)
-		ItemSlots["target carts 2"].Mutate(b)
-		ItemSlots["hidden tokay cave"].Mutate(b)
+		ctx.ItemSlots["nayru's house"].Mutate(b)
+		ctx.ItemSlots["deku forest soldier"].Mutate(b)
+		ctx.ItemSlots["target carts 2"].Mutate(b)
+		ctx.ItemSlots["hidden tokay cave"].Mutate(b)
 	}
 
-	setCompassData(b, game)
+	ctx.setCompassData(b)
 
-	outSum := sha1.Sum(b)
-	return outSum[:], nil
+	return b, finishROM(b, version), nil
 }
 
-// Verify checks all the package's data against the ROM to see if it matches.
+// Verify checks all the context's data against the ROM to see if it matches.
 // It returns a slice of errors describing each mismatch.
-func Verify(b []byte, game int) []error {
+func (ctx *Context) Verify(b []byte) []error {
 	errors := make([]error, 0)
-	for k, m := range getAllMutables() {
+	for k, m := range ctx.getAllMutables() {
+		// code in expanded banks has nothing to check against
+		if outsideROM(m, len(b)) {
+			continue
+		}
+
 		// ignore special cases that would error even when correct
 		switch k {
 		// flutes
@@ -241,7 +335,13 @@ func Verify(b []byte, game int) []error {
 			"tokkey's composition", "rescue nayru", "bracelet 2", "flippers 2":
 			break
 		default:
-			if err := m.Check(b); err != nil {
+			var err error
+			if slot, ok := m.(*MutableSlot); ok {
+				err = ctx.checkVanillaSlot(b, k, slot)
+			} else {
+				err = m.Check(b)
+			}
+			if err != nil {
 				errors = append(errors, fmt.Errorf("%s: %v", k, err))
 			}
 		}
@@ -253,31 +353,44 @@ func Verify(b []byte, game int) []error {
 	return nil
 }
 
+// returns true if the mutable writes anywhere past the end of ROM data of the
+// given size.
+func outsideROM(m Mutable, size int) bool {
+	if mr, ok := m.(*MutableRange); ok {
+		for _, addr := range mr.Addrs {
+			if addr.fullOffset()+len(mr.New) > size {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // set the initial satchel and slingshot seeds (and selections) based on what
 // grows on the horon village tree, and set the map icon for each tree to match
 // the seed type.
-func setSeedData(game int) {
+func (ctx *Context) setSeedData() {
 	var seedType byte
-	if game == GameSeasons {
-		seedType = ItemSlots["horon village seed tree"].Treasure.id
+	if ctx.game == GameSeasons {
+		seedType = ctx.ItemSlots["horon village seed tree"].Treasure.id
 	} else {
-		seedType = ItemSlots["south lynna tree"].Treasure.id
+		seedType = ctx.ItemSlots["south lynna tree"].Treasure.id
 	}
 
-	if game == GameSeasons {
+	if ctx.game == GameSeasons {
 		for _, name := range []string{"satchel initial seeds",
 			"carry seeds in slingshot"} {
-			mut := varMutables[name].(*MutableRange)
+			mut := ctx.varMutables[name].(*MutableRange)
 			mut.New[0] = 0x20 + seedType
 		}
 
 		// slingshot starting seeds
-		varMutables["edit gain/lose items tables"].(*MutableRange).New[1] =
+		ctx.varMutables["edit gain/lose items tables"].(*MutableRange).New[1] =
 			0x20 + seedType
 
 		for _, name := range []string{
 			"satchel initial selection", "slingshot initial selection"} {
-			mut := varMutables[name].(*MutableRange)
+			mut := ctx.varMutables[name].(*MutableRange)
 			mut.New[1] = seedType
 		}
 
@@ -289,42 +402,42 @@ func setSeedData(game int) {
 			"sunken city seed tree map icon",
 			"tarm ruins seed tree map icon",
 		} {
-			mut := varMutables[name].(*MutableRange)
+			mut := ctx.varMutables[name].(*MutableRange)
 			slotName := strings.Replace(name, " map icon", "", 1)
-			id := ItemSlots[slotName].Treasure.id
+			id := ctx.ItemSlots[slotName].Treasure.id
 			mut.New[0] = 0x15 + id
 		}
 	} else {
 		// set high nybbles (seed types) of seed tree interactions
-		setTreeNybble(varMutables["symmetry city tree sub ID"],
-			ItemSlots["symmetry city tree"])
-		setTreeNybble(varMutables["south lynna present tree sub ID"],
-			ItemSlots["south lynna tree"])
-		setTreeNybble(varMutables["crescent island tree sub ID"],
-			ItemSlots["crescent island tree"])
-		setTreeNybble(varMutables["zora village present tree sub ID"],
-			ItemSlots["zora village tree"])
-		setTreeNybble(varMutables["rolling ridge west tree sub ID"],
-			ItemSlots["rolling ridge west tree"])
-		setTreeNybble(varMutables["ambi's palace tree sub ID"],
-			ItemSlots["ambi's palace tree"])
-		setTreeNybble(varMutables["rolling ridge east tree sub ID"],
-			ItemSlots["rolling ridge east tree"])
-		setTreeNybble(varMutables["south lynna past tree sub ID"],
-			ItemSlots["south lynna tree"])
-		setTreeNybble(varMutables["deku forest tree sub ID"],
-			ItemSlots["deku forest tree"])
-		setTreeNybble(varMutables["zora village past tree sub ID"],
-			ItemSlots["zora village tree"])
+		setTreeNybble(ctx.varMutables["symmetry city tree sub ID"],
+			ctx.ItemSlots["symmetry city tree"])
+		setTreeNybble(ctx.varMutables["south lynna present tree sub ID"],
+			ctx.ItemSlots["south lynna tree"])
+		setTreeNybble(ctx.varMutables["crescent island tree sub ID"],
+			ctx.ItemSlots["crescent island tree"])
+		setTreeNybble(ctx.varMutables["zora village present tree sub ID"],
+			ctx.ItemSlots["zora village tree"])
+		setTreeNybble(ctx.varMutables["rolling ridge west tree sub ID"],
+			ctx.ItemSlots["rolling ridge west tree"])
+		setTreeNybble(ctx.varMutables["ambi's palace tree sub ID"],
+			ctx.ItemSlots["ambi's palace tree"])
+		setTreeNybble(ctx.varMutables["rolling ridge east tree sub ID"],
+			ctx.ItemSlots["rolling ridge east tree"])
+		setTreeNybble(ctx.varMutables["south lynna past tree sub ID"],
+			ctx.ItemSlots["south lynna tree"])
+		setTreeNybble(ctx.varMutables["deku forest tree sub ID"],
+			ctx.ItemSlots["deku forest tree"])
+		setTreeNybble(ctx.varMutables["zora village past tree sub ID"],
+			ctx.ItemSlots["zora village tree"])
 
 		// satchel and shooter come with south lynna tree seeds
-		mut := varMutables["satchel initial seeds"].(*MutableRange)
+		mut := ctx.varMutables["satchel initial seeds"].(*MutableRange)
 		mut.New[0] = 0x20 + seedType
-		mut = codeMutables["fill seed shooter"].(*MutableRange)
+		mut = ctx.codeMutables["fill seed shooter"].(*MutableRange)
 		mut.New[6] = 0x20 + seedType
 		for _, name := range []string{"satchel initial selection",
 			"shooter initial selection"} {
-			mut := varMutables[name].(*MutableRange)
+			mut := ctx.varMutables[name].(*MutableRange)
 			mut.New[1] = seedType
 		}
 
@@ -333,8 +446,8 @@ func setSeedData(game int) {
 			"symmetry city tree", "south lynna tree", "zora village tree",
 			"rolling ridge west tree", "ambi's palace tree",
 			"rolling ridge east tree", "deku forest tree"} {
-			mut := varMutables[name+" map icon"].(*MutableRange)
-			mut.New[0] = 0x15 + ItemSlots[name].Treasure.id
+			mut := ctx.varMutables[name+" map icon"].(*MutableRange)
+			mut.New[0] = 0x15 + ctx.ItemSlots[name].Treasure.id
 		}
 	}
 }
@@ -345,19 +458,44 @@ func setTreeNybble(subID Mutable, slot *MutableSlot) {
 	mut.New[0] = (mut.Old[0] & 0x0f) | (slot.Treasure.id << 4)
 }
 
+// set the addresses of slots whose item IDs are in new code, which can only be
+// known after the code is allocated.
+func (ctx *Context) setCodeSlotAddrs() {
+	if ctx.game == GameSeasons {
+		codeAddr := ctx.codeMutables["star ore id func"].(*MutableRange).Addrs[0]
+		ctx.ItemSlots["subrosia seaside"].idAddrs[0].offset = codeAddr.offset + 2
+		ctx.ItemSlots["subrosia seaside"].subIDAddrs[0].offset = codeAddr.offset + 5
+		codeAddr = ctx.codeMutables["hard ore id func"].(*MutableRange).Addrs[0]
+		ctx.ItemSlots["great furnace"].idAddrs[0].offset = codeAddr.offset + 2
+		ctx.ItemSlots["great furnace"].subIDAddrs[0].offset = codeAddr.offset + 5
+		codeAddr = ctx.codeMutables["diver fake id script"].(*MutableRange).Addrs[0]
+		slot := ctx.ItemSlots["master diver's reward"]
+		slot.idAddrs[0].offset = codeAddr.offset + 1
+		slot.subIDAddrs[0].offset = codeAddr.offset + 2
+	} else {
+		mut := ctx.codeMutables["soldier script give item"].(*MutableRange)
+		slot := ctx.ItemSlots["deku forest soldier"]
+		slot.idAddrs[0].offset = mut.Addrs[0].offset + 13
+		slot.subIDAddrs[0].offset = mut.Addrs[0].offset + 14
+		codeAddr := ctx.codeMutables["target carts flag"].(*MutableRange).Addrs[0]
+		ctx.ItemSlots["target carts 2"].idAddrs[1].offset = codeAddr.offset + 1
+		ctx.ItemSlots["target carts 2"].subIDAddrs[1].offset = codeAddr.offset + 2
+	}
+}
+
 // set the locations of the sparkles for the jewels on the treasure map.
-func setTreasureMapData() {
+func (ctx *Context) setTreasureMapData() {
 	for _, name := range []string{"round", "pyramid", "square", "x-shaped"} {
-		mut := varMutables[name+" jewel coords"].(*MutableRange)
-		slot := lookupItemSlot(name + " jewel")
+		mut := ctx.varMutables[name+" jewel coords"].(*MutableRange)
+		slot := ctx.lookupItemSlot(name + " jewel")
 		mut.New[0] = slot.mapCoords
 	}
 }
 
 // match the compass's beep beep beep boops to the actual boss key locations.
-func setCompassData(b []byte, game int) {
+func (ctx *Context) setCompassData(b []byte) {
 	var names []string
-	if game == GameSeasons {
+	if ctx.game == GameSeasons {
 		names = []string{"d1 goriya chest", "d2 terrace chest",
 			"d3 giant blade room", "d4 dive spot", "d5 basement",
 			"d6 escape room", "d7 stalfos chest", "d8 pols voice chest"}
@@ -369,27 +507,32 @@ func setCompassData(b []byte, game int) {
 
 	// clear original boss key flags
 	for _, name := range names {
-		slot := ItemSlots[name]
-		offset :=
-			getDungeonPropertiesAddr(game, slot.group, slot.room).fullOffset()
+		slot := ctx.ItemSlots[name]
+		offset := ctx.getDungeonPropertiesAddr(slot.group, slot.room).fullOffset()
 		b[offset] = b[offset] & 0xef // reset bit 4
 	}
 
-	// add new boss key flags
+	// add new boss key flags. a boss key that's outside its own dungeon, or
+	// isn't in the game at all, doesn't get one.
 	for i := 1; i <= 8; i++ {
-		name := fmt.Sprintf("d%d boss key", i)
-		slot := lookupItemSlot(name)
-		offset :=
-			getDungeonPropertiesAddr(game, slot.group, slot.room).fullOffset()
-		b[offset] = (b[offset] & 0xbf) | 0x10 // set bit 4, reset bit 6
+		treasure := ctx.Treasures[fmt.Sprintf("d%d boss key", i)]
+		prefix := fmt.Sprintf("d%d ", i)
+		for name, slot := range ctx.ItemSlots {
+			if slot.Treasure != treasure || !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			offset := ctx.getDungeonPropertiesAddr(
+				slot.group, slot.room).fullOffset()
+			b[offset] = (b[offset] & 0xbf) | 0x10 // set bit 4, reset bit 6
+		}
 	}
 }
 
 // returns the slot where the named item was placed. this only works for unique
 // items, of course.
-func lookupItemSlot(itemName string) *MutableSlot {
-	t := Treasures[itemName]
-	for _, slot := range ItemSlots {
+func (ctx *Context) lookupItemSlot(itemName string) *MutableSlot {
+	t := ctx.Treasures[itemName]
+	for _, slot := range ctx.ItemSlots {
 		if slot.Treasure == t {
 			return slot
 		}
@@ -398,9 +541,9 @@ func lookupItemSlot(itemName string) *MutableSlot {
 }
 
 // get the location of the dungeon properties byte for a specific room.
-func getDungeonPropertiesAddr(game int, group, room byte) *Addr {
+func (ctx *Context) getDungeonPropertiesAddr(group, room byte) *Addr {
 	offset := uint16(room)
-	if game == GameSeasons {
+	if ctx.game == GameSeasons {
 		offset += 0x4d41
 	} else {
 		offset += 0x4dce
@@ -1,36 +1,370 @@
 package rom
 
-import "testing"
-
-func init() {
-	Init(GameAges) // XXX have to change this manually to test each game
-}
+import (
+	"sort"
+	"strings"
+	"testing"
+)
 
 func TestGraphicsPresent(t *testing.T) {
-	for name, _ := range Treasures {
-		if itemGfx[name] == 0 {
-			t.Errorf("no graphics for %s", name)
+	for _, game := range []int{GameAges, GameSeasons} {
+		ctx := NewContext(game)
+		for name, _ := range ctx.Treasures {
+			if ctx.itemGfx[name] == 0 {
+				t.Errorf("game %d: no graphics for %s", game, name)
+			}
 		}
 	}
 }
 
+func TestContexts(t *testing.T) {
+	a, b := NewContext(GameAges), NewContext(GameAges)
+	a.SetMusic(true)
+	a.ItemSlots["starting chest"].Treasure = a.Treasures["fist ring"]
+	if _, _, err := a.Mutate(make([]byte, 0x100000), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	mut := b.codeMutables["no music call"].(*MutableRange)
+	if string(mut.New) == string(mut.Old) {
+		t.Error("music was turned on in another context")
+	}
+	if name := b.FindTreasureName(
+		b.ItemSlots["starting chest"].Treasure); name != "sword 1" {
+		t.Errorf("want sword 1 in other context, got %s", name)
+	}
+}
+
 func TestMutableOverlap(t *testing.T) {
-	hitBytes := make(map[int]*string)
-
-	for k, v := range getAllMutables() {
-		k := k
-		switch v := v.(type) {
-		case *MutableRange:
-			for _, addr := range v.Addrs {
-				offset := addr.fullOffset()
-				for i := offset; i < offset+len(v.New); i++ {
-					if hitBytes[i] != nil {
-						t.Errorf("%s collides with %s at %d",
-							k, *hitBytes[i], i)
-					}
-					hitBytes[i] = &k
-				}
+	for _, game := range []int{GameAges, GameSeasons} {
+		for _, err := range NewContext(game).checkOverlap() {
+			t.Errorf("game %d: %v", game, err)
+		}
+	}
+}
+
+func TestStartingItems(t *testing.T) {
+	ctx := NewContext(GameAges)
+	if err := ctx.SetStartingItems(
+		[]string{"sword 1", "sword 2", "rupees, 100"}); err != nil {
+		t.Fatal(err)
+	}
+	b := ctx.codeMutables["starting items"].(*MutableRange).New
+	want := []byte{0x05, 0x01, 0x05, 0x02, 0x28, 0x0c, 0xff}
+	for i, v := range want {
+		if b[i] != v {
+			t.Errorf("want %x, got %x", want, b[:len(want)])
+			break
+		}
+	}
+	if len(b) != 2*maxStartingItems+1 {
+		t.Errorf("want %d bytes, got %d", 2*maxStartingItems+1, len(b))
+	}
+
+	for _, names := range [][]string{
+		{"ember tree seeds"},
+		{"not an item"},
+		make([]string, maxStartingItems+1),
+	} {
+		if err := ctx.CheckStartingItems(names); err == nil {
+			t.Errorf("no error for starting items %q", names)
+		}
+	}
+}
+
+func TestSetRings(t *testing.T) {
+	ctx := NewContext(GameAges)
+	if err := ctx.SetRings(map[string]string{
+		"fist ring": "toss ring",
+		"toss ring": "blue ring",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	fist, toss := ctx.Treasures["fist ring"], ctx.Treasures["toss ring"]
+	if fist.addr != agesTreasures["toss ring"].addr || fist.param != 0x3d {
+		t.Errorf("fist ring has wrong data: %+v", fist)
+	}
+	if toss.addr != agesTreasures["blue ring"].addr || toss.param != 0x12 {
+		t.Errorf("toss ring has wrong data: %+v", toss)
+	}
+	if agesTreasures["toss ring"].param != 0x12 {
+		t.Error("vanilla toss ring was changed")
+	}
+
+	if err := ctx.SetRings(map[string]string{"sword 1": "toss ring"}); err == nil {
+		t.Error("no error for non-ring")
+	}
+}
+
+func TestSeedHash(t *testing.T) {
+	ctx := NewContext(GameAges)
+	hash := []byte{0x00, 0x01, 0x02, 0xfe, 0xff}
+	names := ctx.SeedHash(hash)
+	if len(names) != SeedHashLength {
+		t.Fatalf("want %d names, got %d", SeedHashLength, len(names))
+	}
+	for i, name := range names {
+		if ctx.itemGfx[name] == 0 {
+			t.Errorf("no graphics for hash item %s", name)
+		}
+		if other := ctx.SeedHash(hash)[i]; other != name {
+			t.Errorf("hash item %d changed from %s to %s", i, name, other)
+		}
+	}
+
+	// items that look the same shouldn't both be used
+	seen := make(map[int]string)
+	for _, name := range ctx.seedHashNames() {
+		if other, ok := seen[ctx.itemGfx[name]]; ok {
+			t.Errorf("%s and %s have the same icon", name, other)
+		}
+		seen[ctx.itemGfx[name]] = name
+	}
+}
+
+func TestFinishROM(t *testing.T) {
+	b := make([]byte, 0x100000)
+	copy(b[0x134:], "ZELDA NAYRU")
+	if v := StampedVersion(b); v != "" {
+		t.Errorf("unstamped ROM has version %q", v)
+	}
+
+	finishROM(b, "1.2.3")
+	if v := StampedVersion(b); v != "1.2.3" {
+		t.Errorf("want version 1.2.3, got %q", v)
+	}
+
+	var header byte
+	for i := 0x134; i <= 0x14c; i++ {
+		header -= b[i] + 1
+	}
+	if b[0x14d] != header {
+		t.Errorf("want header checksum %02x, got %02x", header, b[0x14d])
+	}
+	var global uint16
+	for i, v := range b {
+		if i < 0x14e || i > 0x14f {
+			global += uint16(v)
+		}
+	}
+	if got := uint16(b[0x14e])<<8 | uint16(b[0x14f]); got != global {
+		t.Errorf("want global checksum %04x, got %04x", global, got)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	ctx := NewContext(GameAges)
+	b := make([]byte, 0x100000)
+	addr := ctx.varMutables["animal region"].(*MutableRange).Addrs[0]
+	b[addr.fullOffset()] = 0x0c
+	slot := ctx.ItemSlots["grave under tree"]
+	if err := slot.Mutate(b); err != nil {
+		t.Fatal(err)
+	}
+
+	ins := NewContext(GameAges).Inspect(b)
+	if ins.Companion != 2 {
+		t.Errorf("want companion 2, got %d", ins.Companion)
+	}
+	if item := ins.Items["grave under tree"]; item != "graveyard key" {
+		t.Errorf("want graveyard key, got %q", item)
+	}
+
+	// an ID that isn't any treasure should be flagged, not guessed at
+	b[slot.idAddrs[0].fullOffset()] = 0xff
+	ins = NewContext(GameAges).Inspect(b)
+	if _, ok := ins.Items["grave under tree"]; ok {
+		t.Error("unknown treasure was identified")
+	}
+	found := false
+	for _, s := range ins.Unknown {
+		if strings.HasPrefix(s, "grave under tree:") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("unknown treasure wasn't flagged")
+	}
+
+	// a small key chest is only a slot if its key is shuffled
+	ctx = NewContext(GameSeasons)
+	slot = ctx.keySlots["d0 key chest"]
+	slot.Treasure = ctx.Treasures["small key"]
+	if err := slot.Mutate(b); err != nil {
+		t.Fatal(err)
+	}
+	ins = NewContext(GameSeasons).Inspect(b)
+	if item, ok := ins.Items["d0 key chest"]; ok {
+		t.Errorf("unshuffled key chest identified as %s", item)
+	}
+}
+
+// check that Inspect reads back what Mutate writes to every slot, including
+// slots whose addresses are set by Mutate and items that only exist in
+// randomized seeds.
+func TestMutateInspect(t *testing.T) {
+	for game, gameName := range map[int]string{
+		GameSeasons: "seasons",
+		GameAges:    "ages",
+	} {
+		ctx := NewContext(game)
+		ctx.SetD0KeyShuffle(true)
+		ctx.SetBossKeysAnywhere(true)
+		ctx.SetAnimal(2)
+
+		// give each slot the item from the next slot, so that no slot keeps
+		// its vanilla item.
+		var names []string
+		for name, slot := range ctx.ItemSlots {
+			if len(slot.idAddrs) > 0 &&
+				!strings.HasSuffix(slot.treasureName, " tree seeds") {
+				names = append(names, name)
 			}
 		}
+		sort.Strings(names)
+		first := ctx.ItemSlots[names[0]].Treasure
+		for i, name := range names {
+			slot := ctx.ItemSlots[name]
+			if i == len(names)-1 {
+				slot.Treasure = first
+			} else {
+				slot.Treasure = ctx.ItemSlots[names[i+1]].Treasure
+			}
+			// only the companion's flute is ever in a seed
+			if slot.Treasure.id == 0x0e {
+				slot.Treasure = ctx.Treasures["dimitri's flute"]
+			}
+		}
+		if game == GameSeasons {
+			ctx.ItemSlots["d1 stalfos chest"].Treasure =
+				ctx.Treasures["d0 small key"]
+			ctx.ItemSlots["horon village SE chest"].Treasure =
+				ctx.Treasures["d3 compass"]
+			ctx.ItemSlots["d0 key chest"].Treasure =
+				ctx.Treasures["d5 dungeon map"]
+		}
+
+		b := make([]byte, 0x100000)
+		if game == GameSeasons {
+			// the dungeon item treasures need sub ID tables to replace
+			for id := 0x30; id <= 0x33; id++ {
+				b[(&Addr{0x15, uint16(0x5129 + id*4)}).fullOffset()] = 0x80
+			}
+		}
+		b, _, err := ctx.Mutate(b, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ins := NewContext(game).Inspect(b)
+		for _, s := range ins.Unknown {
+			t.Errorf("%s: unknown data: %s", gameName, s)
+		}
+		for _, name := range names {
+			want := ctx.ItemSlots[name].Treasure
+			got := ctx.Treasures[ins.Items[name]]
+			if got == nil || got.id != want.id || (got.id == 0x2d &&
+				got.param != want.param) || (got.id != 0x2d &&
+				got.subID != want.subID) {
+				t.Errorf("%s: want %s in %s, got %q", gameName,
+					ctx.FindTreasureName(want), name, ins.Items[name])
+			}
+		}
+		for name, slot := range ctx.ItemSlots {
+			if strings.HasSuffix(slot.treasureName, " tree seeds") &&
+				ins.Items[name] != slot.treasureName {
+				t.Errorf("%s: want %s in %s, got %q", gameName,
+					slot.treasureName, name, ins.Items[name])
+			}
+		}
+	}
+}
+
+func TestRegions(t *testing.T) {
+	b := make([]byte, 0x150)
+	b[0x14a] = 0x01
+	copy(b[0x13f:], "AZ8E")
+	if region := GetRegion(b); region != RegionUS {
+		t.Errorf("want US, got %s", RegionName(region))
+	}
+	copy(b[0x13f:], "AZ8P")
+	if region := GetRegion(b); region != RegionEU {
+		t.Errorf("want EU, got %s", RegionName(region))
+	}
+	b[0x14a] = 0x00
+	if region := GetRegion(b); region != RegionJP {
+		t.Errorf("want JP, got %s", RegionName(region))
+	}
+
+	if err := CheckRegion(RegionUS); err != nil {
+		t.Error(err)
+	}
+	if err := CheckRegion(RegionEU); err == nil {
+		t.Error("no error for unsupported region")
+	}
+}
+
+func TestSymbols(t *testing.T) {
+	ctx := NewContext(GameAges)
+	lines := strings.Split(strings.TrimSpace(ctx.Symbols()), "\n")
+	want := map[string]bool{
+		"no_music_func":       false,
+		"animal_region":       false,
+		"grave_under_tree_id": false,
+	}
+	prev := ""
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || len(fields[0]) != len("00:0000") {
+			t.Errorf("bad symbol line %q", line)
+			continue
+		}
+		if fields[0] < prev {
+			t.Errorf("%s is out of order", line)
+		}
+		prev = fields[0]
+		if _, ok := want[fields[1]]; ok {
+			want[fields[1]] = true
+		}
+	}
+	for label, found := range want {
+		if !found {
+			t.Errorf("no symbol for %s", label)
+		}
+	}
+}
+
+func TestPatchMap(t *testing.T) {
+	ctx := NewContext(GameAges)
+	vanilla := make([]byte, 0x100000)
+	b := make([]byte, len(vanilla))
+	copy(b, vanilla)
+	b, _, err := ctx.Mutate(b, "1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b[0x3fff] ^= 0xff // not owned by anything
+
+	owned, unclaimed := false, false
+	for _, pr := range ctx.PatchMap(vanilla, b) {
+		if len(pr.Old) != len(pr.New) {
+			t.Errorf("%s: %d old bytes and %d new", pr.Owner,
+				len(pr.Old), len(pr.New))
+		}
+		switch pr.Owner {
+		case "no music func":
+			owned = true
+		case "":
+			if pr.Location() == "00:3fff" {
+				unclaimed = true
+			}
+		}
+	}
+	if !owned {
+		t.Error("no range for no music func")
+	}
+	if !unclaimed {
+		t.Error("unclaimed change not flagged")
 	}
 }
@@ -4,28 +4,25 @@ import (
 	"strings"
 )
 
+// free space at the end of each bank, where code and data can be added.
 func newSeasonsRomBanks() *romBanks {
-	r := romBanks{
-		endOfBank: make([]uint16, 0x40),
-	}
-
-	r.endOfBank[0x00] = 0x3ec8
-	r.endOfBank[0x01] = 0x7e89
-	r.endOfBank[0x02] = 0x75bb
-	r.endOfBank[0x03] = 0x7dd7
-	r.endOfBank[0x04] = 0x7e02
-	r.endOfBank[0x05] = 0x7e2d
-	r.endOfBank[0x06] = 0x77d4
-	r.endOfBank[0x07] = 0x78f0
-	r.endOfBank[0x08] = 0x7fc0
-	r.endOfBank[0x09] = 0x7f4e
-	r.endOfBank[0x0a] = 0x7bea
-	r.endOfBank[0x0b] = 0x7f6d
-	r.endOfBank[0x11] = 0x7eb0
-	r.endOfBank[0x15] = 0x792d
-	r.endOfBank[0x3f] = 0x714d
-
-	return &r
+	return newRomBanks([]freeRegion{
+		{0x00, 0x3ec8, 0x4000},
+		{0x01, 0x7e89, 0x8000},
+		{0x02, 0x75bb, 0x8000},
+		{0x03, 0x7dd7, 0x8000},
+		{0x04, 0x7e02, 0x8000},
+		{0x05, 0x7e2d, 0x8000},
+		{0x06, 0x77d4, 0x8000},
+		{0x07, 0x78f0, 0x8000},
+		{0x08, 0x7fc0, 0x8000},
+		{0x09, 0x7f4e, 0x8000},
+		{0x0a, 0x7bea, 0x8000},
+		{0x0b, 0x7f6d, 0x8000},
+		{0x11, 0x7eb0, 0x8000},
+		{0x15, 0x792d, 0x8000},
+		{0x3f, 0x714d, stampOffset},
+	})
 }
 
 // for some reason the maku tree has a different room for every number of
@@ -35,8 +32,12 @@ var (
 	starOreRooms  = []byte{0x66, 0x76, 0x75, 0x65}
 )
 
-func initSeasonsEOB() {
+func (ctx *Context) initSeasonsEOB() {
 	r := newSeasonsRomBanks()
+	if ctx.expanded {
+		r.addExpandedBanks()
+	}
+	ctx.banks = r
 
 	// try to order these first by bank, then by call location. maybe group
 	// them into subfunctions when applicable?
@@ -44,95 +45,225 @@ func initSeasonsEOB() {
 	// bank 00
 
 	// don't play any music if the -nomusic flag is given.
-	noMusicFunc := r.appendToBank(0x00, "no music func",
-		"\x67\xfe\x47\x30\x03\x3e\x08\xc9\xf0\xb5\xc9")
-	r.replace(0x00, 0x0c76, "no music call",
-		"\x67\xf0\xb5", "\xcd"+noMusicFunc)
+	r.appendASM(0x00, "no music func", `
+		ld h,a
+		cp a,$47
+		jr nc,play
+		ld a,$08
+		ret
+	play:
+		ldh a,($b5)
+		ret`)
+	r.replaceASM(0x00, 0x0c76, "no music call",
+		"\x67\xf0\xb5", "call no_music_func")
 
 	// force the item in the temple of seasons cutscene to use normal item
 	// animations.
-	rodCutsceneGfxFunc := r.appendToBank(0x00, "rod cutscene gfx func",
-		"\x1e\x41\x1a\xfe\xe6\xc0\x1c\x1a\xfe\x02\x28\x03\x1d\x1a\xc9"+
-			"\x3e\x60\xc9")
-	r.replace(0x00, 0x2600, "rod cutscene gfx call",
-		"\x1e\x41\x1a", "\xcd"+rodCutsceneGfxFunc)
+	r.appendASM(0x00, "rod cutscene gfx func", `
+		ld e,$41
+		ld a,(de)
+		cp a,$e6
+		ret nz
+		inc e
+		ld a,(de)
+		cp a,$02
+		jr z,rod
+		dec e
+		ld a,(de)
+		ret
+	rod:
+		ld a,$60
+		ret`)
+	r.replaceASM(0x00, 0x2600, "rod cutscene gfx call",
+		"\x1e\x41\x1a", "call rod_cutscene_gfx_func")
 
 	// set hl = address of treasure data + 1 for item with ID a, sub ID c.
-	treasureDataBody := r.appendToBank(0x15, "treasure data body",
-		"\x78\xc5\x21\x29\x51\xcd\xc3\x01\x09"+ // add ID offset
-			"\xcb\x7e\x28\x09\x23\x2a\x66\x6f"+ // load as address if bit 7 set
-			"\xc1\x79\xc5\x18\xef"+ // use sub ID as second offset
-			"\x23\x06\x03\xd5\x11\xfd\xcd\xcd\x62\x04"+ // copy data
-			"\x21\xfd\xcd\xd1\xc1\xc9") // set hl and ret
-	getTreasureData := r.appendToBank(0x00, "treasure data func",
-		"\xf5\xc5\xd5\x47\x1e\x15\x21"+treasureDataBody+
-			"\xcd\x8a\x00\xd1\xc1\xf1\xc9")
+	r.appendASM(0x15, "treasure data body", `
+		ld a,b ; add ID offset
+		push bc
+		ld hl,$5129
+	loop:
+		call $01c3
+		add hl,bc
+		bit 7,(hl) ; load as address if bit 7 set
+		jr z,next
+		inc hl
+		ld a,(hl+)
+		ld h,(hl)
+		ld l,a
+		pop bc ; use sub ID as second offset
+		ld a,c
+		push bc
+		jr loop
+	next:
+		inc hl ; copy data
+		ld b,$03
+		push de
+		ld de,$cdfd
+		call $0462
+		ld hl,$cdfd
+		pop de
+		pop bc
+		ret ; set hl and ret`)
+	r.appendASM(0x00, "treasure data func", `
+		push af
+		push bc
+		push de
+		ld b,a
+		ld e,$15
+		ld hl,treasure_data_body
+		call $008a
+		pop de
+		pop bc
+		pop af
+		ret`)
 
 	// use cape graphics for stolen feather if applicable.
-	upgradeFeather := r.appendToBank(0x00, "upgrade stolen feather func",
+	r.appendToBank(0x00, "upgrade stolen feather func",
 		"\xcd\x17\x17\xd8\xf5\x7b"+ // ret if you have the item
 			"\xfe\x17\x20\x13\xd5\x1e\x43\x1a\xfe\x02\xd1\x20\x0a"+ // check IDs
 			"\xfa\xb4\xc6\xfe\x02\x20\x03"+ // check feather level
 			"\x21\x89\x3f\xf1\xc9"+ // set hl if match
 			"\x02\x37\x17") // treasure data
 	// treasure data
-	progData := r.endOfBank[0x00]
 	r.appendToBank(0x00, "progressive item data",
 		"\x02\x1d\x11\x02\x23\x1d\x02\x2f\x22\x02\x28\x17\x00\x46\x20")
 	// change hl to point to different treasure data if the item is progressive
 	// and needs to be upgraded. param a = treasure ID.
-	progressiveItemFunc := r.appendToBank(0x00, "progressive item func",
-		"\xd5\x5f\xcd"+upgradeFeather+"\x7b\xd1\xd0"+ // ret if missing L-1
-			"\xfe\x05\x20\x04\x21"+addrString(progData)+"\xc9"+ // sword
-			"\xfe\x06\x20\x04\x21"+addrString(progData+3)+"\xc9"+ // boomerang
-			"\xfe\x13\x20\x04\x21"+addrString(progData+6)+"\xc9"+ // slingshot
-			"\xfe\x17\x20\x04\x21"+addrString(progData+9)+"\xc9"+ // feather
-			"\xfe\x19\xc0\x21"+addrString(progData+12)+"\xc9") // satchel
+	r.appendASM(0x00, "progressive item func", `
+		push de
+		ld e,a
+		call upgrade_stolen_feather_func
+		ld a,e
+		pop de
+		ret nc ; ret if missing L-1
+		cp a,$05
+		jr nz,boomerang
+		ld hl,progressive_item_data
+		ret
+	boomerang:
+		cp a,$06
+		jr nz,slingshot
+		ld hl,progressive_item_data+3
+		ret
+	slingshot:
+		cp a,$13
+		jr nz,feather
+		ld hl,progressive_item_data+6
+		ret
+	feather:
+		cp a,$17
+		jr nz,satchel
+		ld hl,progressive_item_data+9
+		ret
+	satchel:
+		cp a,$19
+		ret nz
+		ld hl,progressive_item_data+12
+		ret`)
 
 	// this is a replacement for giveTreasure that gives treasure, plays sound,
 	// and sets text based on item ID a and sub ID c, and accounting for item
 	// progression.
-	giveItem := r.appendToBank(0x00, "give item func",
-		"\xcd"+getTreasureData+"\xcd"+progressiveItemFunc+ // get treasure data
-			"\x4e\xcd\xeb\x16\x28\x05\xe5\xcd\x74\x0c\xe1"+ // give, play sound
-			"\x06\x00\x23\x4e\xcd\x4b\x18\xaf\xc9") // show text
+	r.appendASM(0x00, "give item func", `
+		call treasure_data_func ; get treasure data
+		call progressive_item_func
+		ld c,(hl) ; give, play sound
+		call $16eb
+		jr z,next
+		push hl
+		call $0c74
+		pop hl
+	next:
+		ld b,$00
+		inc hl
+		ld c,(hl)
+		call $184b
+		xor a
+		ret ; show text`)
 
 	// utility function, call a function hl in bank 02, preserving af. e can't
 	// be used as a parameter to that function, but it can be returned.
-	callBank2 := r.appendToBank(0x00, "call bank 02",
-		"\xf5\x1e\x02\xcd\x8a\x00\xf1\xc9")
+	r.appendASM(0x00, "call bank 02", `
+		push af
+		ld e,$02
+		call $008a
+		pop af
+		ret`)
 
 	// increment (hl) until it equals either register a or ff. returns z if a
 	// match was found.
-	searchValue := r.appendToBank(0x00, "search value",
-		"\xc5\x47\x2a\xb8\x28\x06\x3c\x28\x02\x18\xf7\x3c\x78\xc1\xc9")
+	r.appendASM(0x00, "search value", `
+		push bc
+		ld b,a
+	loop:
+		ld a,(hl+)
+		cp b
+		jr z,next
+		inc a
+		jr z,next2
+		jr loop
+	next2:
+		inc a
+	next:
+		ld a,b
+		pop bc
+		ret`)
 
 	// bank 01
 
 	// helper function, takes b = high byte of season addr, returns season in b
-	readSeason := r.appendToBank(0x01, "read default season",
-		"\x26\x7e\x68\x7e\x47\xc9")
+	r.appendASM(0x01, "read default season", `
+		ld h,$7e
+		ld l,b
+		ld a,(hl)
+		ld b,a
+		ret`)
 
 	// bank 02
 
 	// warp to ember tree if holding start when closing the map screen.
-	treeWarp := r.appendToBank(0x02, "tree warp",
-		"\xfa\x81\xc4\xe6\x08\x28\x16"+ // close as normal if start not held
-			"\xfa\x50\xcc\xe6\x01\x20\x06"+ // check if indoors
-			"\x3e\x5a\xcd\x74\x0c\xc9"+ // play error sound and ret
-			"\x21\xb7\xcb\x36\x05\xaf\xcd\x7b\x5e\xc3\x7b\x4f") // close + warp
+	treeWarp := r.appendASM(0x02, "tree warp", `
+		ld a,($c481) ; close as normal if start not held
+		and $08
+		jr z,next
+		ld a,($cc50) ; check if indoors
+		and $01
+		jr nz,next2
+		ld a,$5a ; play error sound and ret
+		call $0c74
+		ret
+	next2:
+		ld hl,$cbb7
+		ld (hl),$05
+		xor a
+		call $5e7b
+	next:
+		jp $4f7b ; close + warp`)
 	r.replaceMultiple([]Addr{{0x02, 0x6089}, {0x02, 0x602c}}, "tree warp jump",
 		"\xc2\x7b\x4f", "\xc4"+treeWarp)
 
 	// warp to room under cursor if wearing developer ring.
-	devWarp := r.appendToBank(0x02, "dev ring warp func",
-		"\xfa\xc5\xc6\xfe\x40\x20\x12\xfa\x49\xcc\xfe\x02\x30\x0b\xf6\x80"+
-			"\xea\x63\xcc\xfa\xb6\xcb\xea\x64\xcc\x3e\x03\xcd\x89\x0c\xc9")
+	devWarp := r.appendASM(0x02, "dev ring warp func", `
+		ld a,($c6c5)
+		cp a,$40
+		jr nz,next
+		ld a,($cc49)
+		cp a,$02
+		jr nc,next
+		or $80
+		ld ($cc63),a
+		ld a,($cbb6)
+		ld ($cc64),a
+	next:
+		ld a,$03
+		call $0c89
+		ret`)
 	r.replace(0x02, 0x5e9b, "dev ring warp call", "\x89\x0c", devWarp)
 
 	// load a custom room layout for the problematic woods of winter screen in
 	// winter. the code here is one 8-tile compression block per line.
-	winterLayout := r.appendToBank(0x02, "winter layout",
+	r.appendToBank(0x02, "winter layout",
 		"\x55\x80\x81\x81\x81\x81"+
 			"\x7c\x16\x80\x82\x17"+
 			"\xf0\x1b\xc4\xc4\x70\x72"+
@@ -143,18 +274,39 @@ func initSeasonsEOB() {
 			"\x30\x1b\x99\x9b\xd9\x1a\x01\x19"+
 			"\x00\x70\x71\x15\x16\x17\xf7\x7a\x8c"+
 			"\x11\x18\x19\x80\x81\x01\x19\x70")
-	loadWinterLayout := r.appendToBank(0x00, "load winter layout",
-		"\xd5\xfa\x4c\xcc\xfe\x9d\x20\x14\xfa\x4e\xcc\xfe\x03\x20\x0d"+
-			"\xfa\x49\xcc\xb7\x20\x07\x3e\x02\xe0\x8c\x21"+winterLayout+
-			"\xf0\x8c\xc3\xe2\x39")
-	r.replace(0x00, 0x39df, "jump to winter layout",
-		"\xd5\xf0\x8c", "\xc3"+loadWinterLayout)
+	r.appendASM(0x00, "load winter layout", `
+		push de
+		ld a,($cc4c)
+		cp a,$9d
+		jr nz,next
+		ld a,($cc4e)
+		cp a,$03
+		jr nz,next
+		ld a,($cc49)
+		or a
+		jr nz,next
+		ld a,$02
+		ldh ($8c),a
+		ld hl,winter_layout
+	next:
+		ldh a,($8c)
+		jp $39e2`)
+	r.replaceASM(0x00, 0x39df, "jump to winter layout",
+		"\xd5\xf0\x8c", "jp load_winter_layout")
 
 	// bank 03
 
 	// allow skipping the capcom screen after one second by pressing start
-	skipCapcom := r.appendToBank(0x03, "skip capcom func",
-		"\xe5\xfa\xb3\xcb\xfe\x94\x30\x03\xcd\x62\x08\xe1\xcd\x37\x02\xc9")
+	skipCapcom := r.appendASM(0x03, "skip capcom func", `
+		push hl
+		ld a,($cbb3)
+		cp a,$94
+		jr nc,next
+		call $0862
+	next:
+		pop hl
+		call $0237
+		ret`)
 	r.replace(0x03, 0x4d6c, "skip capcom call", "\x37\x02", skipCapcom)
 
 	// bank 04
@@ -163,7 +315,7 @@ func initSeasonsEOB() {
 	// set the animal companion to appear right outside instead of where you
 	// left them. table entries are {entered group, entered room, animal room,
 	// saved y, saved x}.
-	animalSaveTable := r.appendToBank(0x04, "animal save point table",
+	r.appendToBank(0x04, "animal save point table",
 		"\x04\xfa\xc2\x18\x68\x00"+ // square jewel cave
 			"\x05\xcc\x2a\x38\x18\x00"+ // goron mountain cave
 			"\x05\xb3\x8e\x58\x88\x00"+ // cave outside d2
@@ -174,69 +326,178 @@ func initSeasonsEOB() {
 			"\x01\x05\x9a\x38\x48\x00"+ // rosa portal
 			"\x04\x39\x8d\x38\x38\x00"+ // d2 entrance
 			"\xff") // end
-	animalSaveFunc := r.appendToBank(0x04, "animal save point func",
-		// b = group, c = room, d = animal room, hl = table
-		"\xc5\xd5\x47\xfa\x64\xcc\x4f\xfa\x42\xcc\x57\x21"+animalSaveTable+
-			"\x2a\xb8\x20\x12\x2a\xb9\x20\x0e\x7e\xba\x20\x0a"+ // check criteria
-			"\x11\x42\xcc\x06\x03\xcd\x62\x04\x18\x0a"+ // set save pt, done
-			"\x2a\xb7\x20\xfc\x7e\x3c\x28\x02\x18\xe0"+ // go to next table entry
-			"\x79\xd1\xc1\xc9") // done
-	r.replace(0x04, 0x461e, "animal save point call",
-		"\xfa\x64\xcc", "\xcd"+animalSaveFunc)
+	r.appendASM(0x04, "animal save point func", `
+		; b = group, c = room, d = animal room, hl = table
+		push bc
+		push de
+		ld b,a
+		ld a,($cc64)
+		ld c,a
+		ld a,($cc42)
+		ld d,a
+		ld hl,animal_save_point_table
+	loop:
+		ld a,(hl+) ; check criteria
+		cp b
+		jr nz,next
+		ld a,(hl+)
+		cp c
+		jr nz,next
+		ld a,(hl)
+		cp d
+		jr nz,next
+		ld de,$cc42 ; set save pt, done
+		ld b,$03
+		call $0462
+		jr next2
+	next:
+		ld a,(hl+) ; go to next table entry
+		or a
+		jr nz,next
+		ld a,(hl)
+		inc a
+		jr z,next2
+		jr loop
+	next2:
+		ld a,c
+		pop de
+		pop bc
+		ret ; done`)
+	r.replaceASM(0x04, 0x461e, "animal save point call",
+		"\xfa\x64\xcc", "call animal_save_point_func")
 
 	// bank 05
 
 	// do this so that animals don't immediately stop walking on screen when
 	// called on a bridge.
-	fluteEnterFunc := r.appendToBank(0x05, "flute enter func",
-		"\xcd\xaa\x44\xb7\xc8\xfe\x1a\xc8\xfe\x1b\xc9")
+	fluteEnterFunc := r.appendASM(0x05, "flute enter func", `
+		call $44aa
+		or a
+		ret z
+		cp a,$1a
+		ret z
+		cp a,$1b
+		ret`)
 	r.replaceMultiple([]Addr{{0x05, 0x71ea}, {0x05, 0x493b}},
 		"animal enter call", "\xcd\xaa\x44\xb7", "\xcd"+fluteEnterFunc+"\x00")
 
 	// let link jump down the cliff outside d7, in case of winter sans shovel.
 	// also let link jump down the snow cliff added in woods of winter. also
 	// lets link jump over any tile if wearing dev ring while shielding.
-	cliffLookupFunc := r.appendToBank(0x05, "cliff lookup func",
-		"\xf5\xfa\xc5\xc6\xfe\x40\x20\x0c\xfa\x89\xcc\xb7\x28\x06"+ // dev
-			"\xf1\xfa\x09\xd0\x37\xc9"+ // always jump if dev ring + shield
-			"\xfa\x49\xcc\xb7\x20\x21"+ // cp group
-			"\xfa\x4c\xcc\xfe\xd0\x20\x09\xf1"+ // d7 entrance
-			"\xfe\xa8\x20\x16\x3e\x08\x37\xc9"+ // cp tile
-			"\xfe\x9d\x20\x0d\xf1"+ // woods of winter
-			"\xfe\x99\x28\x04\xfe\x9b\x20\x05\x3e\x10\x37\xc9"+ // cp tile
-			"\xf1\xc3\xdd\x1d") // jp to normal lookup
-	r.replace(0x05, 0x5fe8, "cliff lookup call",
-		"\xcd\xdd\x1d", "\xcd"+cliffLookupFunc)
+	r.appendASM(0x05, "cliff lookup func", `
+		push af ; dev
+		ld a,($c6c5)
+		cp a,$40
+		jr nz,next
+		ld a,($cc89)
+		or a
+		jr z,next
+		pop af ; always jump if dev ring + shield
+		ld a,($d009)
+		scf
+		ret
+	next:
+		ld a,($cc49) ; cp group
+		or a
+		jr nz,next2
+		ld a,($cc4c) ; d7 entrance
+		cp a,$d0
+		jr nz,next3
+		pop af
+		cp a,$a8 ; cp tile
+		jr nz,next4
+		ld a,$08
+		scf
+		ret
+	next3:
+		cp a,$9d ; woods of winter
+		jr nz,next2
+		pop af
+		cp a,$99 ; cp tile
+		jr z,next5
+		cp a,$9b
+		jr nz,next4
+	next5:
+		ld a,$10
+		scf
+		ret
+	next2:
+		pop af
+	next4:
+		jp $1ddd ; jp to normal lookup`)
+	r.replaceASM(0x05, 0x5fe8, "cliff lookup call",
+		"\xcd\xdd\x1d", "call cliff_lookup_func")
 
 	// bank 06
 
 	// replace a random item drop with gale seeds 1/4 of the time if the player
 	// is out of gale seeds. this is important so that the one-way cliffs can
 	// be in logic with gale seeds.
-	galeDrop := r.appendToBank(0x06, "gale drop func",
-		"\x3e\x23\xcd\x17\x17\xd0\x2e\xb8\xb6\xc0"+
-			"\xcd\x1a\x04\xfe\x40\xd0\x0e\x08\xc9")
-	galeDropWrapper := r.appendToBank(0x06, "gale drop wrapper",
-		"\xcd"+galeDrop+"\xcd\xa7\x3e\xc9")
-	r.replace(0x06, 0x47f5, "gale drop call",
-		"\xcd\xa7\x3e", "\xcd"+galeDropWrapper)
+	r.appendASM(0x06, "gale drop func", `
+		ld a,$23
+		call $1717
+		ret nc
+		ld l,$b8
+		or (hl)
+		ret nz
+		call $041a
+		cp a,$40
+		ret nc
+		ld c,$08
+		ret`)
+	r.appendASM(0x06, "gale drop wrapper", `
+		call gale_drop_func
+		call $3ea7
+		ret`)
+	r.replaceASM(0x06, 0x47f5, "gale drop call",
+		"\xcd\xa7\x3e", "call gale_drop_wrapper")
 
 	// bank 07
 
 	// don't warp link using gale seeds if no trees have been reached (the menu
 	// gets stuck in an infinite loop)
-	galeSeedCheck := r.appendToBank(0x07, "gale seed check",
-		"\xfa\x50\xcc\x3d\xc0\xaf\x21\xf8\xc7\xb6\x21\x9e\xc7\xb6\x21\x72\xc7"+
-			"\xb6\x21\x67\xc7\xb6\x21\x5f\xc7\xb6\x21\x10\xc7\xb6\xcb\x67"+
-			"\x20\x02\x3c\xc9\xaf\xc9")
-	r.replace(0x07, 0x4f45, "call gale seed check",
-		"\xfa\x50\xcc\x3d", "\xcd"+galeSeedCheck+"\x00")
+	r.appendASM(0x07, "gale seed check", `
+		ld a,($cc50)
+		dec a
+		ret nz
+		xor a
+		ld hl,$c7f8
+		or (hl)
+		ld hl,$c79e
+		or (hl)
+		ld hl,$c772
+		or (hl)
+		ld hl,$c767
+		or (hl)
+		ld hl,$c75f
+		or (hl)
+		ld hl,$c710
+		or (hl)
+		bit 4,a
+		jr nz,next
+		inc a
+		ret
+	next:
+		xor a
+		ret`)
+	r.replaceASM(0x07, 0x4f45, "call gale seed check",
+		"\xfa\x50\xcc\x3d", `
+		call gale_seed_check
+		nop`)
 
 	// if wearing dev ring, change season regardless of where link is standing.
-	devChangeSeason := r.appendToBank(0x07, "dev ring season func",
-		"\xfa\xc5\xc6\xfe\x40\xc8\xfa\xb6\xcc\xfe\x08\xc9")
-	r.replace(0x07, 0x5b75, "dev ring season call",
-		"\xfa\xb6\xcc\xfe\x08", "\xcd"+devChangeSeason+"\x00\x00")
+	r.appendASM(0x07, "dev ring season func", `
+		ld a,($c6c5)
+		cp a,$40
+		ret z
+		ld a,($ccb6)
+		cp a,$08
+		ret`)
+	r.replaceASM(0x07, 0x5b75, "dev ring season call",
+		"\xfa\xb6\xcc\xfe\x08", `
+		call dev_ring_season_func
+		nop
+		nop`)
 
 	// bank 08
 
@@ -244,67 +505,188 @@ func initSeasonsEOB() {
 	// one. this obviates some hard-coded shop data (sprite, text) and allows
 	// the item to progressively upgrade.
 	// param = b (item index/subID), returns c,e = treasure ID,subID
-	shopLookup := r.appendToBank(0x08, "shop item lookup",
-		"\x21\xce\x4c\x78\x87\xd7\x4e\x23\x5e\xc9")
-	shopCheckAddr := r.appendToBank(0x08, "shop check addr",
-		"\xfe\xe9\xc8\xfe\xcf\xc8\xfe\xd3\xc8\xfe\xd9\xc9")
-	shopGiveItem := r.appendToBank(0x08, "shop give item func",
-		"\xc5\x47\x7d\xcd"+shopCheckAddr+"\x78\xc1\x28\x04\xcd\xeb\x16\xc9"+
-			"\xcd"+giveItem+"\xc9") // give item and ret
+	r.appendASM(0x08, "shop item lookup", `
+		ld hl,$4cce
+		ld a,b
+		add a,a
+		rst $10
+		ld c,(hl)
+		inc hl
+		ld e,(hl)
+		ret`)
+	r.appendASM(0x08, "shop check addr", `
+		cp a,$e9
+		ret z
+		cp a,$cf
+		ret z
+		cp a,$d3
+		ret z
+		cp a,$d9
+		ret`)
+	shopGiveItem := r.appendASM(0x08, "shop give item func", `
+		push bc
+		ld b,a
+		ld a,l
+		call shop_check_addr
+		ld a,b
+		pop bc
+		jr z,next
+		call $16eb
+		ret
+	next:
+		call give_item_func
+		ret ; give item and ret`)
 	r.replace(0x08, 0x4bfc, "shop give item call",
 		"\xeb\x16", shopGiveItem)
 
 	// give fake treasure 0f for the strange flute item.
-	shopIDFunc := r.appendToBank(0x08, "shop give fake id func",
-		"\x1e\x42\x1a\xfe\x0d\xc0\x21\x93\xc6\xcb\xfe\xc9")
-	r.replace(0x08, 0x4bfe, "shop give fake id call",
-		"\x1e\x42\x1a", "\xcd"+shopIDFunc)
+	r.appendASM(0x08, "shop give fake id func", `
+		ld e,$42
+		ld a,(de)
+		cp a,$0d
+		ret nz
+		ld hl,$c693
+		set 7,(hl)
+		ret`)
+	r.replaceASM(0x08, 0x4bfe, "shop give fake id call",
+		"\x1e\x42\x1a", "call shop_give_fake_id_func")
 
 	// ORs the default season in the given area (low byte b in bank 1) with the
 	// seasons the rod has (c), then ANDs and compares the results with d.
-	warningHelper := r.appendToBank(0x15, "warning helper",
-		"\x1e\x01\x21"+readSeason+"\xcd\x8a\x00"+ // get default season
-			"\x78\xb7\x3e\x01\x28\x05\xcb\x27\x05\x20\xfb"+ // match rod format
-			"\xb1\xa2\xba\xc9") // OR with c, AND with d, compare with d, ret
+	r.appendASM(0x15, "warning helper", `
+		ld e,$01 ; get default season
+		ld hl,read_default_season
+		call $008a
+		ld a,b ; match rod format
+		or a
+		ld a,$01
+		jr z,next
+	loop:
+		sla a
+		dec b
+		jr nz,loop
+	next:
+		or c
+		and d
+		cp d
+		ret ; OR with c, AND with d, compare with d, ret`)
 	// returns c if the player has gale seeds and the seed satchel. used for
 	// warnings for cliffs and diving.
-	checkGaleSatchel := r.appendToBank(0x15, "check gale satchel",
-		"\xc5\x47\x3e\x19\xcd\x17\x17\x30\x05\x3e\x23\xcd\x17\x17\x78\xc1\xc9")
-	warnGeneric := r.appendToBank(0x15, "warn generic",
-		"\xcd\xc6\x3a\xc0\x36\x9f\x2e\x46\x36\x3c"+ // init object
-			"\x01\x00\xf1\x11\x0b\xd0\xcd\x1a\x22"+ // set position
-			"\x3e\x50\xcd\x74\x0c"+ // play sound
-			"\x21\xc0\xcf\xcb\xc6\xc9") // set $cfc0 bit and ret
-	warnCliff := r.appendToBank(0x15, "warn cliff",
-		"\xaf\xea\xe0\xcf\xc3"+warnGeneric)
-	warnFlowerCliff := r.appendToBank(0x15, "warn flower cliff",
-		"\xcd"+checkGaleSatchel+"\xd8"+
-			"\x06\x61\x16\x01\xcd"+warningHelper+"\xc8\xc3"+warnCliff)
-	warnDivingSpot := r.appendToBank(0x15, "warn diving spot",
-		"\x78\xfe\x03\xc8\xcd"+checkGaleSatchel+"\xd8"+
-			"\x06\x61\x16\x09\xcd"+warningHelper+"\xc8\xc3"+warnCliff)
-	warnWaterfallCliff := r.appendToBank(0x15, "warn waterfall cliff",
-		"\xcd"+checkGaleSatchel+"\xd8"+
-			"\x06\x65\x16\x02\xcd"+warningHelper+"\xc8\xc3"+warnCliff)
-	warnMoblinKeep := r.appendToBank(0x15, "warn moblin keep",
-		"\xcd"+checkGaleSatchel+"\xd8"+
-			"\xfa\x10\xc6\xfe\x0c\xc0\x3e\x17\xcd\x17\x17\xd8\xc3"+warnCliff)
-	warnHSSSkip := r.appendToBank(0x15, "warn hss skip",
-		"\xfa\x86\xca\xb7\xc0\xcd\x56\x19\xcb\x76\xc0\xcb\xf6"+
-			"\x3e\x02\xea\xe0\xcf\xc3"+warnGeneric)
-	warnPoeSkip := r.appendToBank(0x15, "warn poe skip",
-		"\xfa\x5a\xca\xcb\x67\xc0"+
-			"\x3e\x08\xcd\x17\x17\xd8\xc3"+warnHSSSkip)
+	r.appendASM(0x15, "check gale satchel", `
+		push bc
+		ld b,a
+		ld a,$19
+		call $1717
+		jr nc,next
+		ld a,$23
+		call $1717
+	next:
+		ld a,b
+		pop bc
+		ret`)
+	r.appendASM(0x15, "warn generic", `
+		call $3ac6 ; init object
+		ret nz
+		ld (hl),$9f
+		ld l,$46
+		ld (hl),$3c
+		ld bc,$f100 ; set position
+		ld de,$d00b
+		call $221a
+		ld a,$50 ; play sound
+		call $0c74
+		ld hl,$cfc0
+		set 0,(hl)
+		ret ; set $cfc0 bit and ret`)
+	r.appendASM(0x15, "warn cliff", `
+		xor a
+		ld ($cfe0),a
+		jp warn_generic`)
+	r.appendASM(0x15, "warn flower cliff", `
+		call check_gale_satchel
+		ret c
+		ld b,$61
+		ld d,$01
+		call warning_helper
+		ret z
+		jp warn_cliff`)
+	r.appendASM(0x15, "warn diving spot", `
+		ld a,b
+		cp a,$03
+		ret z
+		call check_gale_satchel
+		ret c
+		ld b,$61
+		ld d,$09
+		call warning_helper
+		ret z
+		jp warn_cliff`)
+	r.appendASM(0x15, "warn waterfall cliff", `
+		call check_gale_satchel
+		ret c
+		ld b,$65
+		ld d,$02
+		call warning_helper
+		ret z
+		jp warn_cliff`)
+	r.appendASM(0x15, "warn moblin keep", `
+		call check_gale_satchel
+		ret c
+		ld a,($c610)
+		cp a,$0c
+		ret nz
+		ld a,$17
+		call $1717
+		ret c
+		jp warn_cliff`)
+	r.appendASM(0x15, "warn hss skip", `
+		ld a,($ca86)
+		or a
+		ret nz
+		call $1956
+		bit 6,(hl)
+		ret nz
+		set 6,(hl)
+		ld a,$02
+		ld ($cfe0),a
+		jp warn_generic`)
+	r.appendASM(0x15, "warn poe skip", `
+		ld a,($ca5a)
+		bit 4,a
+		ret nz
+		ld a,$08
+		call $1717
+		ret c
+		jp warn_hss_skip`)
 	// this communicates with the warning script by setting bit zero of $cfc0
 	// if the warning needs to be displayed (based on room, season, etc), and
 	// also displays the exclamation mark if so.
-	warningFunc := r.appendToBank(0x15, "warning func",
-		"\xc5\xd5\xcd"+addrString(r.endOfBank[0x15]+8)+"\xd1\xc1\xc9"+ // wrap
-			"\xfa\x4e\xcc\x47\xfa\xb0\xc6\x4f\xfa\x4c\xcc"+ // load env data
-			"\xfe\x46\xca"+warnPoeSkip+"\xfe\x7c\xca"+warnFlowerCliff+
-			"\xfe\x6e\xca"+warnDivingSpot+"\xfe\x3d\xca"+warnWaterfallCliff+
-			"\xfe\x5c\xca"+warnMoblinKeep+"\xfe\x78\xca"+warnHSSSkip+
-			"\xc3"+warnGeneric)
+	warningFunc := r.appendASM(0x15, "warning func", `
+		push bc ; wrap
+		push de
+		call check
+		pop de
+		pop bc
+		ret
+	check:
+		ld a,($cc4e) ; load env data
+		ld b,a
+		ld a,($c6b0)
+		ld c,a
+		ld a,($cc4c)
+		cp a,$46
+		jp z,warn_poe_skip
+		cp a,$7c
+		jp z,warn_flower_cliff
+		cp a,$6e
+		jp z,warn_diving_spot
+		cp a,$3d
+		jp z,warn_waterfall_cliff
+		cp a,$5c
+		jp z,warn_moblin_keep
+		cp a,$78
+		jp z,warn_hss_skip
+		jp warn_generic`)
 	warnCliffText := r.appendToBank(0x0b, "cliff warning script",
 		"\x98\x26\x00\xbe\x00") // show cliff warning text
 	warnBushText := r.appendToBank(0x0b, "bush warning script",
@@ -319,36 +701,79 @@ func initSeasonsEOB() {
 	r.replace(0x08, 0x5663, "warning script pointer", "\x87\x4e", warningScript)
 
 	// set sub ID for star ore
-	starOreIDFunc := r.appendToBank(0x08, "star ore id func",
-		"\x2c\x36\x45\x2c\x36\x00\xc9")
-	r.replace(0x08, 0x62f2, "star ore id call",
-		"\x2c\x36\x45", "\xcd"+starOreIDFunc)
+	r.appendASM(0x08, "star ore id func", `
+		inc l
+		ld (hl),$45
+		inc l
+		ld (hl),$00
+		ret`)
+	r.replaceASM(0x08, 0x62f2, "star ore id call",
+		"\x2c\x36\x45", "call star_ore_id_func")
 
 	// remove volcano cutscene.
-	rmVolcano := r.appendToBank(0x02, "remove volcano scene",
-		"\xcd\x56\x19\xcb\xf6\x11\x44\xd2\x3e\x02\x12\x21\x14\x63\xcd\xfe\x24"+
-			"\x3e\x15\xc3\xcd\x30")
-	r.replace(0x08, 0x7d07, "call remove volcano scene",
-		"\xfa\x18\xcd\xb7\xc0\xcd\x56\x19\xcb\xf6\x3e\x0b\xea\x04\xcc\xcd",
-		"\xfa\x44\xd2\xfe\x01\xc0\xcd\xd9\x3a\x21"+rmVolcano+"\xc3"+callBank2)
-	r.replace(0x08, 0x7cf5, "enable volcano exit",
-		"\xea\xab\xcc", "\x00\x00\x00")
+	r.appendASM(0x02, "remove volcano scene", `
+		call $1956
+		set 6,(hl)
+		ld de,$d244
+		ld a,$02
+		ld (de),a
+		ld hl,$6314
+		call $24fe
+		ld a,$15
+		jp $30cd`)
+	r.replaceASM(0x08, 0x7d07, "call remove volcano scene",
+		"\xfa\x18\xcd\xb7\xc0\xcd\x56\x19\xcb\xf6\x3e\x0b\xea\x04\xcc\xcd", `
+		ld a,($d244)
+		cp a,$01
+		ret nz
+		call $3ad9
+		ld hl,remove_volcano_scene
+		jp call_bank_02`)
+	r.replaceASM(0x08, 0x7cf5, "enable volcano exit",
+		"\xea\xab\xcc", `
+		nop
+		nop
+		nop`)
 
 	// bank 09
 
 	// shared by maku tree and star-shaped ore.
-	starOreRoomTable := r.appendToBank(0x02, "star ore room table",
+	r.appendToBank(0x02, "star ore room table",
 		string(starOreRooms)+"\xff")
-	makuTreeRoomTable := r.appendToBank(0x02, "maku tree room table",
+	r.appendToBank(0x02, "maku tree room table",
 		string(makuTreeRooms)+"\xff")
-	bank2IDFunc := r.appendToBank(0x02, "bank 2 fake id func",
-		"\xfa\x49\xcc\xfe\x01\x28\x05\xfe\x02\x28\x11\xc9"+ // compare group
-			"\xfa\x4c\xcc\x21"+starOreRoomTable+"\xcd"+searchValue+
-			"\xc0\x21\x94\xc6\xcb\xd6\xc9"+
-			"\xfa\x4c\xcc\x21"+makuTreeRoomTable+"\xcd"+searchValue+
-			"\xc0\x21\x93\xc6\xcb\xd6\xc9")
-	bank9IDFunc := r.appendToBank(0x09, "bank 9 fake id func",
-		"\xf5\xe5\x21"+bank2IDFunc+"\xcd"+callBank2+"\xe1\xf1\xcd\xeb\x16\xc9")
+	r.appendASM(0x02, "bank 2 fake id func", `
+		ld a,($cc49) ; compare group
+		cp a,$01
+		jr z,next
+		cp a,$02
+		jr z,next2
+		ret
+	next:
+		ld a,($cc4c)
+		ld hl,star_ore_room_table
+		call search_value
+		ret nz
+		ld hl,$c694
+		set 2,(hl)
+		ret
+	next2:
+		ld a,($cc4c)
+		ld hl,maku_tree_room_table
+		call search_value
+		ret nz
+		ld hl,$c693
+		set 2,(hl)
+		ret`)
+	bank9IDFunc := r.appendASM(0x09, "bank 9 fake id func", `
+		push af
+		push hl
+		ld hl,bank_2_fake_id_func
+		call call_bank_02
+		pop hl
+		pop af
+		call $16eb
+		ret`)
 	r.replace(0x09, 0x42e1, "bank 9 fake id call", "\xeb\x16", bank9IDFunc)
 
 	// animals called by flute normally veto any nonzero collision value for
@@ -357,70 +782,204 @@ func initSeasonsEOB() {
 	// able to call an animal on the d1 screen, or on the bridge to the screen
 	// to the right. the vertical collision check isn't modified, since bridges
 	// only run horizontally.
-	fluteCollisionFunc := r.appendToBank(0x09, "flute collision func",
-		"\x06\x01\x7e\xfe\x1a\x28\x06\xfe\x1b\x28\x02\xb7\xc0"+ // first tile
-			"\x7d\x80\x6f\x7e\xfe\x1a\x28\x05\xfe\x1b\x28\x01\xb7"+ // second
-			"\x7d\xc0\xcd\x89\x20\xaf\xc9") // vanilla stuff
+	fluteCollisionFunc := r.appendASM(0x09, "flute collision func", `
+		ld b,$01 ; first tile
+		ld a,(hl)
+		cp a,$1a
+		jr z,next
+		cp a,$1b
+		jr z,next
+		or a
+		ret nz
+	next:
+		ld a,l ; second
+		add a,b
+		ld l,a
+		ld a,(hl)
+		cp a,$1a
+		jr z,next2
+		cp a,$1b
+		jr z,next2
+		or a
+	next2:
+		ld a,l
+		ret nz
+		call $2089
+		xor a
+		ret ; vanilla stuff`)
 	r.replaceMultiple([]Addr{{0x09, 0x4d9a}, {0x09, 0x4dad}},
 		"flute collision calls", "\xcd\xd9\x4e", "\xcd"+fluteCollisionFunc)
 
 	// remove star ore from inventory when buying the first subrosian market
 	// item. this can't go in the gain/lose items table, since the given item
 	// doesn't necessarily have a unique ID.
-	tradeStarOre := r.appendToBank(0x09, "trade star ore func",
-		"\xb7\x20\x07\xe5\x21\x9a\xc6\xcb\xae\xe1\xdf\x2a\x4e\xc9")
-	r.replace(0x09, 0x7887, "trade star ore call",
-		"\xdf\x2a\x4e", "\xcd"+tradeStarOre)
+	r.appendASM(0x09, "trade star ore func", `
+		or a
+		jr nz,next
+		push hl
+		ld hl,$c69a
+		res 5,(hl)
+		pop hl
+	next:
+		rst $18
+		ld a,(hl+)
+		ld c,(hl)
+		ret`)
+	r.replaceASM(0x09, 0x7887, "trade star ore call",
+		"\xdf\x2a\x4e", "call trade_star_ore_func")
 
 	// use custom "give item" func in the subrosian market.
-	marketFinalGiveItem := r.appendToBank(0x09, "market final give item",
-		"\xf1\xcd"+giveItem+"\xd1\x37\xc9") // give item, scf, ret
-	marketIDFunc := r.appendToBank(0x09, "market give fake id func",
-		"\xe5\x21\x94\xc6\xcb\xc6\xe1\xca"+marketFinalGiveItem)
+	r.appendASM(0x09, "market final give item", `
+		pop af
+		call give_item_func
+		pop de
+		scf
+		ret ; give item, scf, ret`)
+	r.appendASM(0x09, "market give fake id func", `
+		push hl
+		ld hl,$c694
+		set 0,(hl)
+		pop hl
+		jp z,market_final_give_item`)
 	// param = b (item index/subID), returns c,e = treasure ID,subID
-	marketLookup := r.appendToBank(0x09, "market item lookup",
-		"\x21\xda\x77\x78\x87\xd7\x4e\x23\x5e\xc9")
-	marketGiveItem := r.appendToBank(0x09, "market give item func",
-		"\xf5\x7d\xfe\xdb\xca"+marketFinalGiveItem+
-			"\xfe\xe3\xca"+marketFinalGiveItem+"\xfe\xf5\xca"+marketIDFunc+
-			"\xf1\xfe\x2d\x20\x03\xcd\xb9\x17\xcd\xeb\x16\x1e\x42\xc9")
+	r.appendASM(0x09, "market item lookup", `
+		ld hl,$77da
+		ld a,b
+		add a,a
+		rst $10
+		ld c,(hl)
+		inc hl
+		ld e,(hl)
+		ret`)
+	marketGiveItem := r.appendASM(0x09, "market give item func", `
+		push af
+		ld a,l
+		cp a,$db
+		jp z,market_final_give_item
+		cp a,$e3
+		jp z,market_final_give_item
+		cp a,$f5
+		jp z,market_give_fake_id_func
+		pop af
+		cp a,$2d
+		jr nz,next
+		call $17b9
+	next:
+		call $16eb
+		ld e,$42
+		ret`)
 	r.replace(0x09, 0x788a, "market give item call",
 		"\xfe\x2d\x20\x03\xcd\xb9\x17\xcd\xeb\x16\x1e\x42",
 		"\x00\x00\x00\x00\x00\x00\x00\xcd"+marketGiveItem+"\x38\x0b")
 
 	// check treasure id 0a to determine whether the maku tree gives its intro
 	// speech and item, but return the number of essences in a.
-	makuTreeCheckItem := r.appendToBank(0x09, "maku tree check item",
-		"\xcd\x17\x17\xfa\xbb\xc6\xc9")
-	r.replace(0x09, 0x7d93, "maku tree check item call",
-		"\x3e\x40\xcd\x17\x17", "\x3e\x0a\xcd"+makuTreeCheckItem)
+	r.appendASM(0x09, "maku tree check item", `
+		call $1717
+		ld a,($c6bb)
+		ret`)
+	r.replaceASM(0x09, 0x7d93, "maku tree check item call",
+		"\x3e\x40\xcd\x17\x17", `
+		ld a,$0a
+		call maku_tree_check_item`)
 
 	// use a non-cutscene screen transition for exiting a dungeon via essence,
 	// so that overworld music plays, and set maku tree state.
-	essenceWarp := r.appendToBank(0x09, "essence warp",
-		"\x3e\x81\xea\x67\xcc\xfa\xbb\xc6\xcd\x76\x01\xea\xdf\xc6\xc9")
-	r.replace(0x09, 0x4b4f, "call essence warp",
-		"\xea\x67\xcc", "\xcd"+essenceWarp)
+	r.appendASM(0x09, "essence warp", `
+		ld a,$81
+		ld ($cc67),a
+		ld a,($c6bb)
+		call $0176
+		ld ($c6df),a
+		ret`)
+	r.replaceASM(0x09, 0x4b4f, "call essence warp",
+		"\xea\x67\xcc", "call essence_warp")
 
 	// bank 0a
 
 	// set global flags and room flags that would be set during the intro, as
 	// well as some other flags to skip cutscenes, etc.
-	initialGlobalFlags := r.appendToBank(0x0a, "initial global flags",
+	r.appendToBank(0x0a, "initial global flags",
 		"\x0a\x1c\xff")
-	setStartingFlags := r.appendToBank(0x0a, "set starting flags",
-		"\xe5\x21"+initialGlobalFlags+"\x2a\xfe\xff\x28\x07"+
-			"\xe5\xcd\xcd\x30\xe1\x18\xf4\xe1"+ // init global flags
-			"\x3e\xff\xea\x46\xc6"+ // mark animal text as shown
-			"\x3e\x50\xea\xa7\xc7"+ // bits 4 + 6
-			"\x3e\x60\xea\x9a\xc7"+ // bits 5 + 6
-			"\x3e\xc0\xea\x98\xc7\xea\xcb\xc7"+ // bits 6 + 7
-			"\x3e\x40\xea\xb6\xc7\xea\x2a\xc8\xea\x00\xc8"+ // bit 6
-			"\xea\x00\xc7\xea\x96\xc7\xea\x8d\xc7\xea\x60\xc7\xea\xd0\xc7"+
-			"\xea\x1d\xc7\xea\x8a\xc7\xea\xe9\xc7\xea\x9b\xc7\xea\x29\xc8"+
-			"\xc9")
-	r.replace(0x0a, 0x66ed, "call set starting flags",
-		"\x1e\x78\x1a", "\xc3"+setStartingFlags)
+	// boss keys to OR into c67a-c67b, if boss keys are removed from the pool.
+	r.appendToBank(0x0a, "starting boss keys", "\x00\x00")
+	// (ID, param) pairs of treasures to give at the start of the game,
+	// terminated by ff.
+	r.appendToBank(0x0a, "starting items",
+		strings.Repeat("\xff", 2*maxStartingItems+1))
+	r.appendASM(0x0a, "give starting items", `
+		push bc ; push registers, load table
+		push de
+		push hl
+		ld hl,starting_items
+	loop:
+		ld a,(hl+) ; read ID and param, or end
+		cp a,$ff
+		jr z,next
+		ld c,(hl)
+		inc hl
+		push hl ; give treasure and loop
+		call $16eb
+		pop hl
+		jr loop
+	next:
+		pop hl
+		pop de
+		pop bc
+		ret`)
+	r.appendASM(0x0a, "set starting flags", `
+		push hl
+		ld hl,initial_global_flags
+	loop:
+		ld a,(hl+)
+		cp a,$ff
+		jr z,next
+		push hl ; init global flags
+		call $30cd
+		pop hl
+		jr loop
+	next:
+		pop hl
+		ld a,$ff ; mark animal text as shown
+		ld ($c646),a
+		ld a,$50 ; bits 4 + 6
+		ld ($c7a7),a
+		ld a,$60 ; bits 5 + 6
+		ld ($c79a),a
+		ld a,$c0 ; bits 6 + 7
+		ld ($c798),a
+		ld ($c7cb),a
+		ld a,$40 ; bit 6
+		ld ($c7b6),a
+		ld ($c82a),a
+		ld ($c800),a
+		ld ($c700),a
+		ld ($c796),a
+		ld ($c78d),a
+		ld ($c760),a
+		ld ($c7d0),a
+		ld ($c71d),a
+		ld ($c78a),a
+		ld ($c7e9),a
+		ld ($c79b),a
+		ld ($c829),a
+		call give_starting_items
+		push hl ; boss keys
+		push de
+		ld de,starting_boss_keys
+		ld hl,$c67a
+		ld a,(de)
+		or (hl)
+		ld (hl+),a
+		inc de
+		ld a,(de)
+		or (hl)
+		ld (hl),a
+		pop de
+		pop hl
+		ret`)
+	r.replaceASM(0x0a, 0x66ed, "call set starting flags",
+		"\x1e\x78\x1a", "jp set_starting_flags")
 
 	// bank 0b
 
@@ -428,9 +987,18 @@ func initSeasonsEOB() {
 	// cfc0 is set, and set ccaa to 01 meanwhile. fixes a vanilla bug where
 	// dismounting an animal on that screen allowed you to enter without key.
 	r.replace(0x0b, 0x4dea, "d1 entrance script cmd", "\xa0", "\xb2")
-	d1EntranceFunc := r.appendToBank(0x0b, "d1 entrance cmd func",
-		"\xe1\xfa\x49\xcc\xfe\x00\xc0\xfa\x4c\xcc\xfe\x96\xc0"+ // check room
-			"\x3e\x01\xea\xaa\xcc\xaf\xc3\x2d\x43")
+	d1EntranceFunc := r.appendASM(0x0b, "d1 entrance cmd func", `
+		pop hl ; check room
+		ld a,($cc49)
+		cp a,$00
+		ret nz
+		ld a,($cc4c)
+		cp a,$96
+		ret nz
+		ld a,$01
+		ld ($ccaa),a
+		xor a
+		jp $432d`)
 	r.replace(0x0b, 0x406d, "d1 entrance cmd jump", "\x03\x41", d1EntranceFunc)
 
 	diverIDScript := r.appendToBank(0x0b, "diver fake id script",
@@ -439,8 +1007,12 @@ func initSeasonsEOB() {
 		"\xde\x2e\x00", "\xc0"+diverIDScript)
 
 	// returns c,e = treasure ID,subID
-	nobleSwordLookup := r.appendToBank(0x0b, "noble sword lookup",
-		"\x21\x18\x64\x4e\x23\x5e\xc9")
+	r.appendASM(0x0b, "noble sword lookup", `
+		ld hl,$6418
+		ld c,(hl)
+		inc hl
+		ld e,(hl)
+		ret`)
 
 	// bank 11
 
@@ -481,110 +1053,370 @@ func initSeasonsEOB() {
 	// found, the original mode (a) is preserved. the table is three bytes per
 	// entry, (group, room, collect mode). ff ends the table. rooms that
 	// contain more than one item are special cases.
-	collectModeTable := r.appendToBank(0x15, "collection mode table",
-		makeSeasonsCollectModeTable())
+	r.appendToBank(0x15, "collection mode table",
+		ctx.makeSeasonsCollectModeTable())
 	// cp link's position if in diver room, set mode to 02 if on right side,
 	// ret z if set
-	collectModeDiver := r.appendToBank(0x15, "diver collect mode",
-		"\x3e\x05\xb8\xc0\x3e\xbd\xb9\xc0\xfa\x0d\xd0\xfe\x80\xd8"+
-			"\xaf\x3e\x02\xc9")
+	r.appendASM(0x15, "diver collect mode", `
+		ld a,$05
+		cp b
+		ret nz
+		ld a,$bd
+		cp c
+		ret nz
+		ld a,($d00d)
+		cp a,$80
+		ret c
+		xor a
+		ld a,$02
+		ret`)
 	// cp link's position if in d7 compass room, set mode to default if on
 	// left side, ret z if set
-	collectModeD7Key := r.appendToBank(0x15, "d7 key collect mode",
-		"\x3e\x05\xb8\xc0\x3e\x52\xb9\xc0\xfa\x0d\xd0\xfe\x80\xd0"+
-			"\xaf\x7b\xc9")
+	r.appendASM(0x15, "d7 key collect mode", `
+		ld a,$05
+		cp b
+		ret nz
+		ld a,$52
+		cp c
+		ret nz
+		ld a,($d00d)
+		cp a,$80
+		ret nc
+		xor a
+		ld a,e
+		ret`)
 	// if link already has the maku tree's item, use default mode.
-	collectModeMakuSeed := r.appendToBank(0x15, "maku seed collect mode",
-		"\x3e\x02\xb8\xc0\x3e\x5d\xb9\xc0\x3e\x0a\xcd\x17\x17\x38\x02"+
-			"\x3c\xc9\xaf\x7b\xc9")
-	collectModeLookup := r.appendToBank(0x15, "collection mode lookup func",
-		"\x5f\xc5\xe5\xfa\x49\xcc\x47\xfa\x4c\xcc\x4f\x21"+collectModeTable+
-			"\x2a\xfe\xff\x28\x1d\xb8\x20\x16\x2a\xb9\x20\x13"+
-			"\xcd"+collectModeDiver+"\x28\x12\xcd"+collectModeD7Key+"\x28\x0d"+
-			"\xcd"+collectModeMakuSeed+"\x28\x08"+
-			"\x2a\x18\x05\x23\x23\x18\xde\x7b\xe1\xc1\xc9")
+	r.appendASM(0x15, "maku seed collect mode", `
+		ld a,$02
+		cp b
+		ret nz
+		ld a,$5d
+		cp c
+		ret nz
+		ld a,$0a
+		call $1717
+		jr c,next
+		inc a
+		ret
+	next:
+		xor a
+		ld a,e
+		ret`)
+	r.appendASM(0x15, "collection mode lookup func", `
+		ld e,a
+		push bc
+		push hl
+		ld a,($cc49)
+		ld b,a
+		ld a,($cc4c)
+		ld c,a
+		ld hl,collection_mode_table
+	loop:
+		ld a,(hl+)
+		cp a,$ff
+		jr z,next
+		cp b
+		jr nz,next2
+		ld a,(hl+)
+		cp c
+		jr nz,next3
+		call diver_collect_mode
+		jr z,next4
+		call d7_key_collect_mode
+		jr z,next4
+		call maku_seed_collect_mode
+		jr z,next4
+		ld a,(hl+)
+		jr next4
+	next2:
+		inc hl
+	next3:
+		inc hl
+		jr loop
+	next:
+		ld a,e
+	next4:
+		pop hl
+		pop bc
+		ret`)
+
+	// sub ID tables for small keys, boss keys, compasses, and maps (treasures
+	// 30 to 33), used for dungeon items that can leave their dungeons. the
+	// first four entries are copied from the original tables when the ROM is
+	// mutated, and the rest are items for dungeons 0 through 8.
+	for i, name := range dungeonItemTables {
+		table := new(strings.Builder)
+		table.WriteString(strings.Repeat("\x00", 4*4))
+		for j := byte(0); j < 9; j++ {
+			table.Write(seasonsDungeonItem(0x30+byte(i), j).Bytes())
+		}
+		r.appendToBank(0x15, name, table.String())
+	}
 
 	// upgrade normal items (interactions with ID 60) as necessary when they're
 	// created, and set collection mode.
-	normalProgressiveFunc := r.appendToBank(0x15, "normal progressive func",
-		"\xcd"+collectModeLookup+"\x47\xcb\x37\xf5"+
-			"\x1e\x43\x1a\xfe\x02\x30\x05"+ // don't upgrade spin slash
-			"\x1b\x1a\xcd"+progressiveItemFunc+"\xf1\xc9")
-	r.replace(0x15, 0x465a, "set normal progressive call",
-		"\x47\xcb\x37", "\xcd"+normalProgressiveFunc)
+	r.appendASM(0x15, "normal progressive func", `
+		call collection_mode_lookup_func
+		ld b,a
+		swap a
+		push af
+		ld e,$43 ; don't upgrade spin slash
+		ld a,(de)
+		cp a,$02
+		jr nc,next
+		dec de
+		ld a,(de)
+		call progressive_item_func
+	next:
+		pop af
+		ret`)
+	r.replaceASM(0x15, 0x465a, "set normal progressive call",
+		"\x47\xcb\x37", "call normal_progressive_func")
 
 	// should be set to match the western coast season
-	pirateSeason := r.appendToBank(0x15, "season after pirate cutscene", "\x15")
+	r.appendToBank(0x15, "season after pirate cutscene", "\x15")
 	// skip pirate cutscene. includes setting flag $1b, which makes the pirate
 	// skull appear in the desert in case the player hasn't talked to the
 	// ghost yet.
-	pirateFlagFunc := r.appendToBank(0x15, "pirate flag func",
-		"\xcd\xcd\x30\x3e\x17\xcd\xcd\x30\x3e\x1b\xcd\xcd\x30\x21\xe2\xc7"+
-			"\xcb\xf6\xfa"+pirateSeason+"\xea\x4e\xcc\xc9")
+	pirateFlagFunc := r.appendASM(0x15, "pirate flag func", `
+		call $30cd
+		ld a,$17
+		call $30cd
+		ld a,$1b
+		call $30cd
+		ld hl,$c7e2
+		set 6,(hl)
+		ld a,(season_after_pirate_cutscene)
+		ld ($cc4e),a
+		ret`)
 	r.replace(0x15, 0x5a0f, "pirate flag call", "\xcd\x30", pirateFlagFunc)
 
 	// set sub ID for hard ore
-	hardOreFunc := r.appendToBank(0x15, "hard ore id func",
-		"\x2c\x36\x52\x2c\x36\x00\xc9")
-	r.replace(0x15, 0x5b83, "hard ore id call",
-		"\x2c\x36\x52", "\xcd"+hardOreFunc)
+	r.appendASM(0x15, "hard ore id func", `
+		inc l
+		ld (hl),$52
+		inc l
+		ld (hl),$00
+		ret`)
+	r.replaceASM(0x15, 0x5b83, "hard ore id call",
+		"\x2c\x36\x52", "call hard_ore_id_func")
 
 	// use custom "give item" func in rod cutscene.
-	r.replace(0x15, 0x70cf, "rod give item call",
-		"\xcd\xeb\x16", "\xcd"+giveItem)
+	r.replaceASM(0x15, 0x70cf, "rod give item call",
+		"\xcd\xeb\x16", "call give_item_func")
 
 	// bank 3f
 
 	// have seed satchel inherently refill all seeds.
-	satchelRefill := r.appendToBank(0x3f, "satchel seed refill func",
-		"\xc5\xcd\xc8\x44\x78\xc1\xf5\x78\xfe\x19\x20\x07"+
-			"\xc5\xd5\xcd\xe5\x17\xd1\xc1\xf1\x47\xc9")
-	r.replace(0x00, 0x16f6, "satchel refill call",
-		"\xcd\xc8\x44", "\xcd"+satchelRefill)
+	r.appendASM(0x3f, "satchel seed refill func", `
+		push bc
+		call $44c8
+		ld a,b
+		pop bc
+		push af
+		ld a,b
+		cp a,$19
+		jr nz,next
+		push bc
+		push de
+		call $17e5
+		pop de
+		pop bc
+	next:
+		pop af
+		ld b,a
+		ret`)
+	r.replaceASM(0x00, 0x16f6, "satchel refill call",
+		"\xcd\xc8\x44", "call satchel_seed_refill_func")
 
 	// returns c,e = treasure ID,subID
-	rodLookup := r.appendToBank(0x15, "rod lookup",
-		"\x21\xcc\x70\x5e\x23\x23\x4e\xc9")
+	r.appendASM(0x15, "rod lookup", `
+		ld hl,$70cc
+		ld e,(hl)
+		inc hl
+		inc hl
+		ld c,(hl)
+		ret`)
 	// return z if object is randomized shop item.
-	checkShopItem := r.appendToBank(0x3f, "check randomized shop item",
-		"\x79\xfe\x47\xc0\x7b\xb7\xc8\xfe\x02\xc8\xfe\x05\xc8\xfe\x0d\xc9")
+	r.appendASM(0x3f, "check randomized shop item", `
+		ld a,c
+		cp a,$47
+		ret nz
+		ld a,e
+		or a
+		ret z
+		cp a,$02
+		ret z
+		cp a,$05
+		ret z
+		cp a,$0d
+		ret`)
 	// same as above but for subrosia market.
-	checkMarketItem := r.appendToBank(0x3f, "check randomized market item",
-		"\x79\xfe\x81\xc0\x7b\xb7\xc8\xfe\x04\xc8\xfe\x0d\xc9")
+	r.appendASM(0x3f, "check randomized market item", `
+		ld a,c
+		cp a,$81
+		ret nz
+		ld a,e
+		or a
+		ret z
+		cp a,$04
+		ret z
+		cp a,$0d
+		ret`)
 	// and rod of seasons.
-	checkRod := r.appendToBank(0x3f, "check rod",
-		"\x79\xfe\xe6\xc0\x7b\xfe\x02\xc9")
+	r.appendASM(0x3f, "check rod", `
+		ld a,c
+		cp a,$e6
+		ret nz
+		ld a,e
+		cp a,$02
+		ret`)
 	// load gfx data for randomized shop and market items.
-	itemGfxFunc := r.appendToBank(0x3f, "item gfx func",
-		// check for matching object
-		"\x43\x4f\xcd"+checkRod+"\x28\x17\x79\xfe\x59\x28\x19"+ // rod, woods
-			"\xcd"+checkShopItem+"\x28\x1b\xcd"+
-			checkMarketItem+"\x28\x1d"+ // shops
-			"\x79\xfe\x6e\x28\x1f\x06\x00\xc9"+ // feather
-			// look up item ID, subID
-			"\x1e\x15\x21"+rodLookup+"\x18\x1d"+
-			"\x1e\x0b\x21"+nobleSwordLookup+"\x18\x16"+
-			"\x1e\x08\x21"+shopLookup+"\x18\x0f"+
-			"\x1e\x09\x21"+marketLookup+"\x18\x08"+
-			"\xfa\xb4\xc6\xc6\x15\x5f\x18\x0e"+ // feather
-			"\xcd\x8a\x00\x79\x4b\xcd"+getTreasureData+ // get treasure
-			"\xcd"+progressiveItemFunc+"\x23\x23\x5e"+ // get sprite
-			"\x3e\x60\x4f\x06\x00\xc9") // replace object gfx w/ treasure gfx
-	r.replace(0x3f, 0x443c, "item gfx call", "\x4f\x06\x00", "\xcd"+itemGfxFunc)
+	r.appendASM(0x3f, "item gfx func", `
+		; check for matching object
+		ld b,e
+		ld c,a
+		call check_rod ; rod, woods
+		jr z,next
+		ld a,c
+		cp a,$59
+		jr z,next2
+		call check_randomized_shop_item
+		jr z,next3
+		call check_randomized_market_item ; shops
+		jr z,next4
+		ld a,c ; feather
+		cp a,$6e
+		jr z,next5
+		ld b,$00
+		ret
+		; look up item ID, subID
+	next:
+		ld e,$15
+		ld hl,rod_lookup
+		jr next6
+	next2:
+		ld e,$0b
+		ld hl,noble_sword_lookup
+		jr next6
+	next3:
+		ld e,$08
+		ld hl,shop_item_lookup
+		jr next6
+	next4:
+		ld e,$09
+		ld hl,market_item_lookup
+		jr next6
+	next5:
+		ld a,($c6b4) ; feather
+		add a,$15
+		ld e,a
+		jr next7
+	next6:
+		call $008a ; get treasure
+		ld a,c
+		ld c,e
+		call treasure_data_func
+		call progressive_item_func ; get sprite
+		inc hl
+		inc hl
+		ld e,(hl)
+	next7:
+		ld a,$60
+		ld c,a
+		ld b,$00
+		ret ; replace object gfx w/ treasure gfx`)
+	r.replaceASM(0x3f, 0x443c, "item gfx call",
+		"\x4f\x06\x00", "call item_gfx_func")
 
 	// "activate" a flute by setting its icon and song when obtained. also
 	// activates the corresponding animal companion.
-	setFluteIcon := r.appendToBank(0x3f, "flute set icon func",
-		"\xf5\xd5\xe5\x78\xfe\x0e\x20\x15\x1e\xaf\x79\xd6\x0a\x12\xc6\x42"+
-			"\x26\xc6\x6f\xfe\x45\x20\x04\xcb\xee\x18\x02\xcb\xfe"+
-			"\xe1\xd1\xf1\xcd\x4e\x45\xc9")
-	r.replace(0x3f, 0x452c, "flute set icon call", "\x4e\x45", setFluteIcon)
+	r.appendASM(0x3f, "flute set icon func", `
+		push af
+		push de
+		push hl
+		ld a,b
+		cp a,$0e
+		jr nz,next
+		ld e,$af
+		ld a,c
+		sub $0a
+		ld (de),a
+		add a,$42
+		ld h,$c6
+		ld l,a
+		cp a,$45
+		jr nz,next2
+		set 5,(hl)
+		jr next
+	next2:
+		set 7,(hl)
+	next:
+		pop hl
+		pop de
+		pop af
+		call $454e
+		ret`)
+	// give small keys, boss keys, compasses, and maps with a parameter of 80
+	// or higher to the dungeon in the low nybble of the parameter, instead of
+	// the current dungeon. keys are counted by the normal parameter function,
+	// but the others set the dungeon's bit in c67a/c67c/c67e directly.
+	dungeonItemFunc := r.appendASM(0x3f, "dungeon item func", `
+		push af ; check param and ID
+		bit 7,c
+		jr z,next
+		ld a,b
+		cp a,$30
+		jr z,next2
+		jr c,next
+		cp a,$34
+		jr nc,next
+		push bc ; hl = bitset
+		push hl
+		sub $31
+		add a,a
+		add a,$7a
+		ld l,a
+		ld h,$c6
+		ld a,c ; index in byte
+		and $0f
+		cp a,$08
+		jr c,next3
+		sub $08
+		inc l
+	next3:
+		ld b,a ; a = 1 << index
+		inc b
+		ld a,$01
+	loop:
+		dec b
+		jr z,next4
+		add a,a
+		jr loop
+	next4:
+		or (hl) ; set bit and return
+		ld (hl),a
+		pop hl
+		pop bc
+		pop af
+		ret
+	next2:
+		ld a,c ; de = c66a + index
+		and $0f
+		add a,$6a
+		ld e,a
+		ld d,$c6
+		ld c,$01
+	next:
+		pop af
+		jp flute_set_icon_func`)
+	r.replace(0x3f, 0x452c, "flute set icon call", "\x4e\x45", dungeonItemFunc)
 }
 
 // makes seasons-specific additions to the collection mode table.
-func makeSeasonsCollectModeTable() string {
+func (ctx *Context) makeSeasonsCollectModeTable() string {
 	b := new(strings.Builder)
-	table := makeCollectModeTable()
+	table := ctx.makeCollectModeTable()
 	b.WriteString(table[:len(table)-1]) // strip final ff
 
 	// add other three star ore screens
@@ -597,6 +1429,12 @@ func makeSeasonsCollectModeTable() string {
 		b.Write([]byte{0x02, room, collectFall})
 	}
 
+	// add small key chests, with placeholder groups until their rooms are
+	// known. these need to be at the end of the table.
+	for range ctx.keySlots {
+		b.Write([]byte{0xfe, 0x00, collectChest})
+	}
+
 	b.Write([]byte{0xff})
 	return b.String()
 }
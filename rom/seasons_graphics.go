@@ -82,6 +82,6 @@ var seasonsItemGfx = map[string]int{
 
 // rod of seasons has a different graphics whatever than the rest of the slots
 // and it's tricky to change, so i'm restricting items instead.
-func CanSlotAsRod(name string) bool {
-	return (itemGfx[name] & 0xf) == 0
+func (ctx *Context) CanSlotAsRod(name string) bool {
+	return (ctx.itemGfx[name] & 0xf) == 0
 }
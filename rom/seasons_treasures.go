@@ -5,6 +5,18 @@ func seasonsTreasure(id, subID byte, offset uint16,
 	return &Treasure{id, subID, Addr{0x15, offset}, mode, param, text, sprite}
 }
 
+// seasonsDungeonItem returns a fake treasure for a small key (30), boss key
+// (31), compass (32), or dungeon map (33) that belongs to a specific dungeon.
+// the data for these lives in custom sub ID tables, and the parameter is 80 +
+// the dungeon index.
+func seasonsDungeonItem(id, dungeon byte) *Treasure {
+	// text and sprite of the vanilla items, in order of ID
+	text := []byte{0x1a, 0x1b, 0x19, 0x18}[id-0x30]
+	sprite := []byte{0x42, 0x43, 0x41, 0x40}[id-0x30]
+	return &Treasure{id, 0x04 + dungeon, Addr{}, collectChest,
+		0x80 | dungeon, text, sprite}
+}
+
 var seasonsTreasures = map[string]*Treasure{
 	// equip items
 	"wooden shield":   seasonsTreasure(0x01, 0x00, 0x52bd, 0x0a, 0x01, 0x1f, 0x13),
@@ -80,6 +92,29 @@ var seasonsTreasures = map[string]*Treasure{
 	"compass":     seasonsTreasure(0x32, 0x02, 0x5425, 0x68, 0x00, 0x19, 0x41),
 	"dungeon map": seasonsTreasure(0x33, 0x02, 0x5431, 0x68, 0x00, 0x18, 0x40),
 
+	// the hero's cave small key, for when it's shuffled. this doesn't exist
+	// in the vanilla game.
+	"d0 small key": seasonsDungeonItem(0x30, 0),
+
+	// dungeon-specific maps and compasses, for when they can be placed
+	// outside their own dungeons.
+	"d1 compass":     seasonsDungeonItem(0x32, 1),
+	"d2 compass":     seasonsDungeonItem(0x32, 2),
+	"d3 compass":     seasonsDungeonItem(0x32, 3),
+	"d4 compass":     seasonsDungeonItem(0x32, 4),
+	"d5 compass":     seasonsDungeonItem(0x32, 5),
+	"d6 compass":     seasonsDungeonItem(0x32, 6),
+	"d7 compass":     seasonsDungeonItem(0x32, 7),
+	"d8 compass":     seasonsDungeonItem(0x32, 8),
+	"d1 dungeon map": seasonsDungeonItem(0x33, 1),
+	"d2 dungeon map": seasonsDungeonItem(0x33, 2),
+	"d3 dungeon map": seasonsDungeonItem(0x33, 3),
+	"d4 dungeon map": seasonsDungeonItem(0x33, 4),
+	"d5 dungeon map": seasonsDungeonItem(0x33, 5),
+	"d6 dungeon map": seasonsDungeonItem(0x33, 6),
+	"d7 dungeon map": seasonsDungeonItem(0x33, 7),
+	"d8 dungeon map": seasonsDungeonItem(0x33, 8),
+
 	// collection items
 	"ring box L-1":    seasonsTreasure(0x2c, 0x00, 0x53a5, 0x02, 0x01, 0x57, 0x33),
 	"ring box L-2":    seasonsTreasure(0x2c, 0x01, 0x53a9, 0x02, 0x02, 0x34, 0x34),
@@ -0,0 +1,43 @@
+package rom
+
+import "sort"
+
+// SeedHashLength is the number of item names in a seed hash.
+const SeedHashLength = 5
+
+// SeedHash returns the item names that identify a seed with the given hash,
+// for players to compare. The hash must be at least SeedHashLength bytes
+// long.
+func (ctx *Context) SeedHash(hash []byte) []string {
+	names := ctx.seedHashNames()
+	seedHash := make([]string, SeedHashLength)
+	for i := range seedHash {
+		seedHash[i] = names[int(hash[i])%len(names)]
+	}
+	return seedHash
+}
+
+// returns the names of items with distinct icons, in alphabetical order, so
+// that no two names in a hash are for items that look alike. if items share
+// an icon, the shortest name is used, so that e.g. the ring icon is "ring"
+// and not the name of a specific ring.
+func (ctx *Context) seedHashNames() []string {
+	byGfx := make(map[int]string)
+	for name, gfx := range ctx.itemGfx {
+		if gfx == 0 {
+			continue
+		}
+		other, ok := byGfx[gfx]
+		if !ok || len(name) < len(other) ||
+			(len(name) == len(other) && name < other) {
+			byGfx[gfx] = name
+		}
+	}
+
+	names := make([]string, 0, len(byGfx))
+	for _, name := range byGfx {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
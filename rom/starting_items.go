@@ -0,0 +1,68 @@
+package rom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// the most treasures that can be given at the start of the game.
+const maxStartingItems = 16
+
+// CheckStartingItems returns an error if the named treasures can't all be
+// given at the start of the game.
+func (ctx *Context) CheckStartingItems(names []string) error {
+	if len(names) > maxStartingItems {
+		return fmt.Errorf("can't start with more than %d items",
+			maxStartingItems)
+	}
+
+	for _, name := range names {
+		t := ctx.Treasures[name]
+		if t == nil || t.addr.offset == 0 {
+			// seed tree placeholders and dungeon-specific items don't have
+			// real treasure data.
+			return fmt.Errorf("can't start with %s", name)
+		}
+		switch t.id {
+		case 0x30, 0x31, 0x32, 0x33: // small key, boss key, compass, map
+			return fmt.Errorf("can't start with %s", name)
+		}
+	}
+
+	return nil
+}
+
+// SetStartingItems sets the treasures that the player is given at the start
+// of the game. it returns an error if any of them can't be given.
+func (ctx *Context) SetStartingItems(names []string) error {
+	if err := ctx.CheckStartingItems(names); err != nil {
+		return err
+	}
+
+	given := make(map[string]bool, len(names))
+	for _, name := range names {
+		given[name] = true
+	}
+
+	b := make([]byte, 0, 2*maxStartingItems+1)
+	for _, name := range names {
+		t := ctx.Treasures[name]
+		param := t.param
+
+		// progressive items give L-1 first, so the second level has to be
+		// given explicitly.
+		if strings.HasSuffix(name, " 2") &&
+			given[strings.TrimSuffix(name, " 2")+" 1"] {
+			param = 0x02
+		}
+
+		b = append(b, t.id, param)
+	}
+	b = append(b, 0xff)
+	for len(b) < 2*maxStartingItems+1 {
+		b = append(b, 0xff)
+	}
+
+	ctx.codeMutables["starting items"].(*MutableRange).New = b
+	return nil
+}
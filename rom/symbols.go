@@ -0,0 +1,67 @@
+package rom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// a symbol is a labeled address for debugging in an emulator.
+type symbol struct {
+	addr  Addr
+	label string
+}
+
+// adds a symbol for each address, numbering the labels if there's more than
+// one.
+func addSymbols(syms []symbol, label string, addrs []Addr) []symbol {
+	for i, addr := range addrs {
+		s := symbol{addr, label}
+		if len(addrs) > 1 {
+			s.label = fmt.Sprintf("%s_%d", label, i+1)
+		}
+		syms = append(syms, s)
+	}
+	return syms
+}
+
+// Symbols returns the contents of a symbol file that labels the code, data,
+// and item slots that the randomizer changes, in the bank:addr format that
+// BGB, SameBoy, and Emulicious read. Labels are named like asmLabel names.
+// Call it after Mutate, since some addresses aren't final until then.
+func (ctx *Context) Symbols() string {
+	syms := make([]symbol, 0)
+
+	for _, set := range []map[string]Mutable{ctx.codeMutables, ctx.varMutables} {
+		for name, m := range set {
+			if mr, ok := m.(*MutableRange); ok {
+				syms = addSymbols(syms, asmLabel(name), mr.Addrs)
+			}
+		}
+	}
+	for name, slot := range ctx.ItemSlots {
+		label := asmLabel(name)
+		syms = addSymbols(syms, label+"_id", slot.idAddrs)
+		syms = addSymbols(syms, label+"_subid", slot.subIDAddrs)
+		syms = addSymbols(syms, label+"_param", slot.paramAddrs)
+		syms = addSymbols(syms, label+"_text", slot.textAddrs)
+		syms = addSymbols(syms, label+"_gfx", slot.gfxAddrs)
+	}
+
+	sort.Slice(syms, func(i, j int) bool {
+		if syms[i].addr.bank != syms[j].addr.bank {
+			return syms[i].addr.bank < syms[j].addr.bank
+		}
+		if syms[i].addr.offset != syms[j].addr.offset {
+			return syms[i].addr.offset < syms[j].addr.offset
+		}
+		return syms[i].label < syms[j].label
+	})
+
+	b := new(strings.Builder)
+	b.WriteString("; oracles-randomizer symbols\n")
+	for _, s := range syms {
+		fmt.Fprintf(b, "%02x:%04x %s\n", s.addr.bank, s.addr.offset, s.label)
+	}
+	return b.String()
+}
@@ -76,13 +76,10 @@ func (t Treasure) Check(b []byte) error {
 	return nil
 }
 
-// Treasures maps item names to associated treasure data.
-var Treasures map[string]*Treasure
-
 // FindTreasureName does a reverse lookup of the treasure in the map to return
 // its name. It returns an empty string if not found.
-func FindTreasureName(t *Treasure) string {
-	for k, v := range Treasures {
+func (ctx *Context) FindTreasureName(t *Treasure) string {
+	for k, v := range ctx.Treasures {
 		if v == t {
 			return k
 		}
@@ -18,12 +18,99 @@ import (
 const maxTries = 50
 
 // adds nodes to the map based on default contents of item slots.
-func addDefaultItemNodes(nodes map[string]*logic.Node) {
-	for key, slot := range rom.ItemSlots {
+func addDefaultItemNodes(ctx *rom.Context, nodes map[string]*logic.Node) {
+	for key, slot := range ctx.ItemSlots {
 		if key != "temple of seasons" { // real rod is an Or, not a Root
-			nodes[rom.FindTreasureName(slot.Treasure)] = logic.Root()
+			nodes[ctx.FindTreasureName(slot.Treasure)] = logic.Root()
 		}
 	}
+
+	// any ring can be in a chest if rings are shuffled
+	for _, name := range rom.RingNames() {
+		nodes[name] = logic.Root()
+	}
+}
+
+// dungeon item placement modes
+const (
+	placeVanilla = iota
+	placeOwnDungeon
+	placeAnywhere
+	placeRemoved
+)
+
+var placeModeNames = []string{"vanilla", "own dungeon", "anywhere", "removed"}
+
+// parseKeyMode returns the small key placement mode for a CLI option value.
+func parseKeyMode(s string) (int, error) {
+	switch s {
+	case "", "off", "vanilla":
+		return placeVanilla, nil
+	case "dungeon":
+		return placeOwnDungeon, nil
+	case "anywhere":
+		return placeAnywhere, nil
+	}
+	return 0, fmt.Errorf(`invalid small key mode "%s"`, s)
+}
+
+// parseItemMode returns the boss key, map, or compass placement mode for a
+// CLI option value.
+func parseItemMode(s string) (int, error) {
+	switch s {
+	case "vanilla":
+		return placeVanilla, nil
+	case "", "dungeon":
+		return placeOwnDungeon, nil
+	case "anywhere":
+		return placeAnywhere, nil
+	case "removed":
+		return placeRemoved, nil
+	}
+	return 0, fmt.Errorf(`invalid dungeon item mode "%s"`, s)
+}
+
+// dungeonItemModes holds the placement mode for each kind of dungeon item.
+type dungeonItemModes struct {
+	SmallKeys, BossKeys, Maps, Compasses int
+}
+
+// itemMode returns the placement mode for the named boss key, map, or
+// compass, or -1 if the item is none of those.
+func (m dungeonItemModes) itemMode(name string) int {
+	switch {
+	case strings.HasSuffix(name, "boss key"):
+		return m.BossKeys
+	case strings.HasSuffix(name, "dungeon map"):
+		return m.Maps
+	case strings.HasSuffix(name, "compass"):
+		return m.Compasses
+	}
+	return -1
+}
+
+// check returns an error if the modes aren't supported by the game.
+func (m dungeonItemModes) check(game int) error {
+	if game == rom.GameSeasons {
+		return nil
+	}
+	if m.SmallKeys != placeVanilla {
+		return fmt.Errorf("small keys can't be shuffled in ages")
+	}
+	if m.BossKeys == placeAnywhere || m.Maps == placeAnywhere ||
+		m.Compasses == placeAnywhere {
+		return fmt.Errorf("dungeon items can't be placed anywhere in ages")
+	}
+	return nil
+}
+
+// routeOptions holds the settings that affect item placement and logic.
+type routeOptions struct {
+	Tricks graph.Tricks
+	Modes  dungeonItemModes
+	Rings  bool     // shuffle rings in chests
+	Plan   *plan    // user-specified placements, or nil
+	Start  []string // items given at the start of the game
 }
 
 // A Route is a set of information needed for finding an item placement route.
@@ -31,13 +118,17 @@ type Route struct {
 	Graph  graph.Graph
 	Slots  map[string]*graph.Node
 	Rupees int
+	Modes  dungeonItemModes
+	ROM    *rom.Context // item slots and treasures of the game
 }
 
-// NewRoute returns an initialized route with all nodes, and those nodes with
-// the names in start functioning as givens (always satisfied). If no names are
-// given, only the normal start node functions as a given.
-func NewRoute(game int, start ...string) *Route {
+// NewRoute returns an initialized route for the context's game with all nodes,
+// and those nodes with the names in start functioning as givens (always
+// satisfied). If no names are given, only the normal start node functions as a
+// given.
+func NewRoute(ctx *rom.Context, start ...string) *Route {
 	g := graph.New()
+	game := ctx.Game()
 
 	var totalPrenodes map[string]*logic.Node
 	if game == rom.GameSeasons {
@@ -45,11 +136,40 @@ func NewRoute(game int, start ...string) *Route {
 	} else {
 		totalPrenodes = logic.GetAges()
 	}
-	addDefaultItemNodes(totalPrenodes)
+	addDefaultItemNodes(ctx, totalPrenodes)
+
+	// if small key chests are slots, they give whatever key item is placed
+	// there instead of a fixed key.
+	if game == rom.GameSeasons {
+		for chest, key := range logic.SeasonsKeyChests() {
+			slot := ctx.ItemSlots[chest]
+			if slot == nil {
+				continue
+			}
+
+			pn := totalPrenodes[chest]
+			if pn.Type == logic.OrType {
+				totalPrenodes[chest] = logic.OrSlot(pn.Parents...)
+			} else {
+				totalPrenodes[chest] = logic.AndSlot(pn.Parents...)
+			}
+			totalPrenodes[key] = logic.And(ctx.FindTreasureName(slot.Treasure))
+		}
+	}
 
-	// make start nodes given
+	// dungeon-specific maps and compasses replace the normal ones if they can
+	// be placed outside their own dungeons.
+	if game == rom.GameSeasons {
+		for i := 1; i <= 8; i++ {
+			totalPrenodes[fmt.Sprintf("d%d compass", i)] = logic.Root()
+			totalPrenodes[fmt.Sprintf("d%d dungeon map", i)] = logic.Root()
+		}
+	}
+
+	// make start nodes given. other copies of the same item can still be
+	// placed in slots, which only adds more ways to get it.
 	for _, key := range start {
-		totalPrenodes[key] = logic.And()
+		totalPrenodes[key] = logic.Or("start")
 	}
 
 	addNodes(totalPrenodes, g)
@@ -66,6 +186,7 @@ func NewRoute(game int, start ...string) *Route {
 	return &Route{
 		Graph: g,
 		Slots: openSlots,
+		ROM:   ctx,
 	}
 }
 
@@ -80,17 +201,14 @@ func (r *Route) ClearParents(node string) {
 func addNodes(prenodes map[string]*logic.Node, g graph.Graph) {
 	for key, pn := range prenodes {
 		switch pn.Type {
-		case logic.AndType, logic.AndSlotType, logic.AndStepType,
-			logic.HardAndType:
+		case logic.AndType, logic.AndSlotType, logic.AndStepType:
 			isStep := pn.Type == logic.AndSlotType ||
 				pn.Type == logic.AndStepType
 			isSlot := pn.Type == logic.AndSlotType
-			isHard := pn.Type == logic.HardAndType
 
-			node := graph.NewNode(key, graph.AndType, isStep, isSlot, isHard)
+			node := graph.NewNode(key, graph.AndType, isStep, isSlot, pn.Trick)
 			g.AddNodes(node)
-		case logic.OrType, logic.OrSlotType, logic.OrStepType, logic.RootType,
-			logic.HardOrType:
+		case logic.OrType, logic.OrSlotType, logic.OrStepType, logic.RootType:
 			isStep := pn.Type == logic.OrSlotType ||
 				pn.Type == logic.OrStepType
 			isSlot := pn.Type == logic.OrSlotType
@@ -98,9 +216,8 @@ func addNodes(prenodes map[string]*logic.Node, g graph.Graph) {
 			if pn.Type == logic.RootType {
 				nodeType = graph.RootType
 			}
-			isHard := pn.Type == logic.HardOrType
 
-			node := graph.NewNode(key, nodeType, isStep, isSlot, isHard)
+			node := graph.NewNode(key, nodeType, isStep, isSlot, pn.Trick)
 			g.AddNodes(node)
 		default:
 			panic("unknown logic type for " + key)
@@ -128,6 +245,9 @@ type RouteInfo struct {
 	Route                *Route
 	Seed                 uint32
 	Seasons              map[string]byte
+	Hints                []string
+	SeedHash             []string
+	Rings                map[string]string
 	Companion            int // 1 to 3
 	TunicColor           int // 0 to 3
 	UsedItems, UsedSlots *list.List
@@ -141,9 +261,12 @@ const (
 )
 
 // attempts to create a path to the given targets by placing different items in
-// slots. returns nils if no route is found.
-func findRoute(game int, seed uint32, hard, verbose bool,
+// slots. returns nils if no route is found. the context isn't changed, so
+// routes can be searched for concurrently with the same one.
+func findRoute(ctx *rom.Context, seed uint32, ro routeOptions, verbose bool,
 	logf logFunc) *RouteInfo {
+	game := ctx.Game()
+
 	// make stacks out of the item names and slot names for backtracking
 	var itemList, slotList *list.List
 
@@ -155,6 +278,11 @@ func findRoute(game int, seed uint32, hard, verbose bool,
 		UsedSlots: list.New(),
 	}
 
+	pl := ro.Plan
+	if pl == nil {
+		pl = &plan{}
+	}
+
 	// try to find the route, retrying if needed
 	var src *rand.Rand
 	tries := 0
@@ -162,17 +290,47 @@ func findRoute(game int, seed uint32, hard, verbose bool,
 		src = rand.New(rand.NewSource(int64(ri.Seed)))
 		logf("trying seed %08x", ri.Seed)
 
-		r := NewRoute(game)
-		ri.Companion = rollAnimalCompanion(src, r, game)
+		r := NewRoute(ctx, ro.Start...)
+		r.Modes = ro.Modes
+		for _, name := range ro.Start {
+			r.Rupees += logic.RupeeValues[name]
+		}
+		if ro.Modes.BossKeys == placeRemoved {
+			for i := 1; i <= 8; i++ {
+				r.AddParent(fmt.Sprintf("d%d boss key", i), "start")
+			}
+		}
+		ri.Companion = rollAnimalCompanion(src, r, game, pl.companionID())
 		ri.TunicColor = src.Intn(4)
-		itemList, slotList = initRouteInfo(src, r, game, ri.Companion)
+		itemList, slotList = initRouteInfo(src, r, game, ri.Companion,
+			pl.extraSeeds())
+		removeStartingItems(r, itemList, ro.Start)
+		if ro.Rings {
+			ri.Rings = rollRings(src, r, itemList, pl.placements())
+		}
 
 		// slot initial nodes before algorithm slots progression items
 		if game == rom.GameSeasons {
-			ri.Seasons = rollSeasons(src, r)
+			ri.Seasons = rollSeasons(src, r, pl.Seasons)
+		}
+		if err := pl.place(r,
+			itemList, ri.UsedItems, slotList, ri.UsedSlots); err != nil {
+			logf("abort; plan: %v", err)
+			return nil
 		}
+		// planned items are at the bottom of the stacks, and backtracking
+		// stops before it reaches them.
+		pinned := ri.UsedItems.Len()
 		placeDungeonItems(src, r, game,
 			itemList, ri.UsedItems, slotList, ri.UsedSlots)
+		if reasons := pl.unreachable(r, itemList, ro.Tricks); reasons != nil {
+			// retrying with another seed wouldn't change the plan
+			for _, reason := range reasons {
+				logf("plan: %s", reason)
+			}
+			logf("abort; plan makes the seed unbeatable")
+			return nil
+		}
 
 		slotRecord := 0
 		i, maxIterations := 0, 1+itemList.Len()
@@ -180,14 +338,14 @@ func findRoute(game int, seed uint32, hard, verbose bool,
 		// slot progression items
 		done := r.Graph["done"]
 		success := true
-		for done.GetMark(done, hard) != graph.MarkTrue {
+		for done.GetMark(done, ro.Tricks) != graph.MarkTrue {
 			if verbose {
 				logf("searching; have %d more slots", slotList.Len())
 				logf("%d/%d iterations", i, maxIterations)
 			}
 
 			eItem, eSlot := trySlotRandomItem(r, src, itemList, slotList,
-				countSteps, ri.UsedSlots.Len(), hard, false)
+				countSteps, ri.UsedSlots.Len(), ro.Tricks, false)
 
 			if eItem != nil {
 				item := itemList.Remove(eItem).(*graph.Node)
@@ -200,6 +358,10 @@ func findRoute(game int, seed uint32, hard, verbose bool,
 					slotRecord = ri.UsedSlots.Len()
 					i, maxIterations = 0, 1+itemList.Len()
 				}
+			} else if ri.UsedItems.Len() <= pinned {
+				// nothing to backtrack; the starting position is a dead end
+				success = false
+				break
 			} else {
 				item := ri.UsedItems.Remove(ri.UsedItems.Back()).(*graph.Node)
 				slot := ri.UsedSlots.Remove(ri.UsedSlots.Back()).(*graph.Node)
@@ -230,7 +392,7 @@ func findRoute(game int, seed uint32, hard, verbose bool,
 				}
 
 				eItem, eSlot := trySlotRandomItem(r, src, itemList, slotList,
-					countSteps, ri.UsedSlots.Len(), hard, true)
+					countSteps, ri.UsedSlots.Len(), ro.Tricks, true)
 
 				if eItem != nil {
 					item := itemList.Remove(eItem).(*graph.Node)
@@ -243,6 +405,9 @@ func findRoute(game int, seed uint32, hard, verbose bool,
 						slotRecord = ri.UsedSlots.Len()
 						i, maxIterations = 0, 1+itemList.Len()
 					}
+				} else if ri.UsedItems.Len() <= pinned {
+					success = false
+					break
 				} else {
 					item := ri.UsedItems.Remove(ri.UsedItems.Back()).(*graph.Node)
 					slot := ri.UsedSlots.Remove(ri.UsedSlots.Back()).(*graph.Node)
@@ -293,8 +458,10 @@ var (
 )
 
 // set the default seasons for all the applicable areas in the game, and return
-// a mapping of area name to season value.
-func rollSeasons(src *rand.Rand, r *Route) map[string]byte {
+// a mapping of area name to season value. areas in the planned map keep their
+// planned seasons instead of rolling new ones.
+func rollSeasons(src *rand.Rand, r *Route,
+	planned map[string]string) map[string]byte {
 	seasonMap := make(map[string]byte, len(seasonAreas))
 
 	for _, area := range seasonAreas {
@@ -304,7 +471,16 @@ func rollSeasons(src *rand.Rand, r *Route) map[string]byte {
 		}
 
 		// roll new default season
-		id := src.Intn(len(seasonsByID))
+		var id int
+		if season, ok := planned[area]; ok {
+			for id = range seasonsByID {
+				if seasonsByID[id] == season {
+					break
+				}
+			}
+		} else {
+			id = src.Intn(len(seasonsByID))
+		}
 		season := seasonsByID[id]
 		r.AddParent(fmt.Sprintf("%s default %s", area, season), "start")
 		seasonMap[area] = byte(id)
@@ -313,9 +489,13 @@ func rollSeasons(src *rand.Rand, r *Route) map[string]byte {
 	return seasonMap
 }
 
-// randomly determines animal companion and returns its ID (1 to 3)
-func rollAnimalCompanion(src *rand.Rand, r *Route, game int) int {
-	companion := src.Intn(3) + 1
+// randomly determines animal companion and returns its ID (1 to 3), unless a
+// nonzero planned companion is given.
+func rollAnimalCompanion(src *rand.Rand, r *Route, game, planned int) int {
+	companion := planned
+	if companion == 0 {
+		companion = src.Intn(3) + 1
+	}
 
 	if game == rom.GameSeasons {
 		r.ClearParents("natzu prairie")
@@ -348,6 +528,55 @@ func rollAnimalCompanion(src *rand.Rand, r *Route, game int) int {
 	return companion
 }
 
+// replaces one copy of each starting item in the item pool with filler, since
+// the player already has it.
+func removeStartingItems(r *Route, itemList *list.List, start []string) {
+	for _, name := range start {
+		if e := findNodeInList(name, itemList); e != nil {
+			e.Value = r.Graph["gasha seed"]
+		}
+	}
+}
+
+// replaces each ring in the item pool with a random one, and returns a map of
+// the new rings to the vanilla rings whose data they use. no ring is placed
+// twice. rings in the planned placements are used first, so that the plan can
+// place them.
+func rollRings(src *rand.Rand, r *Route, itemList *list.List,
+	placements map[string]string) map[string]string {
+	names := rom.RingNames()
+	src.Shuffle(len(names), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+
+	planned := make([]string, 0)
+	for _, name := range placements {
+		if r.ROM.IsRing(name) && !stringInSlice(name, planned) {
+			planned = append(planned, name)
+		}
+	}
+	sort.Strings(planned)
+	for _, name := range names {
+		if !stringInSlice(name, planned) {
+			planned = append(planned, name)
+		}
+	}
+	names = planned
+
+	rings := make(map[string]string)
+	for e := itemList.Front(); e != nil; e = e.Next() {
+		item := e.Value.(*graph.Node)
+		if !r.ROM.IsRing(item.Name) {
+			continue
+		}
+		rings[names[0]] = item.Name
+		e.Value = r.Graph[names[0]]
+		names = names[1:]
+	}
+
+	return rings
+}
+
 // dungeonIndex returns the index of a slot's dungeon if it's in a dungeon, or
 // -1 if it's not.
 func dungeonIndex(node *graph.Node) int {
@@ -359,13 +588,40 @@ func dungeonIndex(node *graph.Node) int {
 	return -1
 }
 
-// place maps, compasses, and boss keys in chests in dungeons (before
-// attempting to slot the other ones).
+// place maps, compasses, and boss keys that are restricted to their vanilla
+// slots or their own dungeons (before attempting to slot the other ones).
 func placeDungeonItems(src *rand.Rand, r *Route, game int,
 	itemList, usedItems, slotList, usedSlots *list.List) {
 
+	// return vanilla items to their original slots
+	for es := slotList.Front(); es != nil; {
+		slot := es.Value.(*graph.Node)
+		next := es.Next()
+
+		itemName := ""
+		if romSlot := r.ROM.ItemSlots[slot.Name]; romSlot != nil {
+			itemName = r.ROM.FindTreasureName(romSlot.Treasure)
+		}
+		if r.Modes.itemMode(itemName) == placeVanilla {
+			for ei := itemList.Front(); ei != nil; ei = ei.Next() {
+				item := ei.Value.(*graph.Node)
+				if item.Name == itemName {
+					item.AddParents(slot)
+
+					usedSlots.PushBack(slot)
+					slotList.Remove(es)
+					usedItems.PushBack(item)
+					itemList.Remove(ei)
+					break
+				}
+			}
+		}
+
+		es = next
+	}
+
 	// place boss keys first
-	for i := 1; i < 9; i++ {
+	for i := 1; i < 9 && r.Modes.BossKeys == placeOwnDungeon; i++ {
 		slotted := false
 		for ei := itemList.Front(); ei != nil && !slotted; ei = ei.Next() {
 			item := ei.Value.(*graph.Node)
@@ -396,9 +652,17 @@ func placeDungeonItems(src *rand.Rand, r *Route, game int,
 	}
 	prefixes = append(prefixes, "d7", "d8")
 
+	itemNames := make([]string, 0, 2)
+	if r.Modes.Maps == placeOwnDungeon {
+		itemNames = append(itemNames, "dungeon map")
+	}
+	if r.Modes.Compasses == placeOwnDungeon {
+		itemNames = append(itemNames, "compass")
+	}
+
 	// then place maps and compasses
 	for _, prefix := range prefixes {
-		for _, itemName := range []string{"dungeon map", "compass"} {
+		for _, itemName := range itemNames {
 			slotElem, itemElem, slotNode, itemNode :=
 				getDungeonItem(prefix, itemName, slotList, itemList)
 
@@ -452,21 +716,22 @@ func fillList(l *list.List, a []*graph.Node) {
 var seedNames = []string{"ember tree seeds", "scent tree seeds",
 	"pegasus tree seeds", "gale tree seeds", "mystery tree seeds"}
 
-// return shuffled lists of item and slot nodes
-func initRouteInfo(src *rand.Rand, r *Route,
-	game, companion int) (itemList, slotList *list.List) {
+// return shuffled lists of item and slot nodes. duplicate seed trees use the
+// given extra seed types before random ones.
+func initRouteInfo(src *rand.Rand, r *Route, game, companion int,
+	extraSeeds []string) (itemList, slotList *list.List) {
 	// get slices of names
 	var itemNames []string
 	if game == rom.GameSeasons {
 		itemNames = make([]string, 0,
-			len(rom.ItemSlots)+len(logic.SeasonsExtraItems()))
+			len(r.ROM.ItemSlots)+len(logic.SeasonsExtraItems()))
 	} else {
-		itemNames = make([]string, 0, len(rom.ItemSlots))
+		itemNames = make([]string, 0, len(r.ROM.ItemSlots))
 	}
 	slotNames := make([]string, 0, len(r.Slots))
 	thisSeedNames := make([]string, len(seedNames))
 	copy(thisSeedNames, seedNames)
-	for key, slot := range rom.ItemSlots {
+	for key, slot := range r.ROM.ItemSlots {
 		switch key {
 		case "temple of seasons": // don't slot vanilla, seasonless rod
 			break
@@ -474,14 +739,25 @@ func initRouteInfo(src *rand.Rand, r *Route,
 			"rolling ridge east tree", "zora village tree":
 			// use random duplicate seed types, but only duplicate a seed type
 			// once
-			index := src.Intn(len(thisSeedNames))
+			index := -1
+			for len(extraSeeds) > 0 && index == -1 {
+				for i, name := range thisSeedNames {
+					if name == extraSeeds[0] {
+						index = i
+					}
+				}
+				extraSeeds = extraSeeds[1:]
+			}
+			if index == -1 {
+				index = src.Intn(len(thisSeedNames))
+			}
 			treasureName := thisSeedNames[index]
 			itemNames = append(itemNames, treasureName)
 			thisSeedNames = append(thisSeedNames[:index],
 				thisSeedNames[index+1:]...)
 		default:
 			// substitute identified flute for strange flute
-			treasureName := rom.FindTreasureName(slot.Treasure)
+			treasureName := r.ROM.FindTreasureName(slot.Treasure)
 			if treasureName == "strange flute" {
 				switch companion {
 				case ricky:
@@ -493,6 +769,19 @@ func initRouteInfo(src *rand.Rand, r *Route,
 				}
 			}
 
+			// substitute filler for removed dungeon items, and
+			// dungeon-specific maps and compasses for ones that can go
+			// anywhere.
+			switch r.Modes.itemMode(treasureName) {
+			case placeRemoved:
+				treasureName = "gasha seed"
+			case placeAnywhere:
+				if treasureName == "dungeon map" || treasureName == "compass" {
+					treasureName = fmt.Sprintf("%s %s",
+						strings.SplitN(key, " ", 2)[0], treasureName)
+				}
+			}
+
 			itemNames = append(itemNames, treasureName)
 		}
 	}
@@ -530,12 +819,12 @@ func initRouteInfo(src *rand.Rand, r *Route,
 }
 
 // return the number of "step" nodes in the given set
-func countSteps(r *Route, hard bool) int {
+func countSteps(r *Route, tricks graph.Tricks) int {
 	r.Graph.ClearMarks()
-	reached := r.Graph.ExploreFromStart(hard)
+	reached := r.Graph.ExploreFromStart(tricks)
 	count := 0
 	for node := range reached {
-		if node.IsStep && canAffordSlot(r, node, hard) {
+		if node.IsStep && canAffordSlot(r, node, tricks) {
 			count++
 		}
 	}
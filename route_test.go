@@ -1,6 +1,12 @@
 package main
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/jangler/oracles-randomizer/graph"
@@ -15,19 +21,19 @@ func TestGraph(t *testing.T) {
 
 // check that graph logic is working as expected
 func testSeasonsGraph(t *testing.T) {
-	rom.Init(rom.GameSeasons)
-	r := NewRoute(rom.GameSeasons)
+	ctx := rom.NewContext(rom.GameSeasons)
+	r := NewRoute(ctx)
 	g := r.Graph
 
 	checkReach(t, g,
 		map[string]string{
 			"feather 1": "d0 sword chest",
-		}, "maku tree", false, false)
+		}, "maku tree", nil, false)
 
 	checkReach(t, g,
 		map[string]string{
 			"sword 1": "d0 sword chest",
-		}, "maku tree", false, true)
+		}, "maku tree", nil, true)
 
 	checkReach(t, g,
 		map[string]string{
@@ -35,13 +41,13 @@ func testSeasonsGraph(t *testing.T) {
 			"ember tree seeds": "horon village seed tree",
 			"satchel 1":        "maku tree",
 			"member's card":    "d0 rupee chest",
-		}, "member's shop 1", false, true)
+		}, "member's shop 1", nil, true)
 
 	checkReach(t, g,
 		map[string]string{
 			"sword 1":  "d0 sword chest",
 			"bracelet": "maku tree",
-		}, "floodgate keeper's house", false, false)
+		}, "floodgate keeper's house", nil, false)
 
 	checkReach(t, g,
 		map[string]string{
@@ -53,9 +59,9 @@ func testSeasonsGraph(t *testing.T) {
 
 			"woods of winter default summer": "",
 			"woods of winter default winter": "start",
-		}, "holly's house", false, false)
+		}, "holly's house", nil, false)
 
-	// check normal vs hard
+	// check normal logic vs tricks
 	checkReach(t, g,
 		map[string]string{
 			"sword 1":            "d0 sword chest",
@@ -66,7 +72,7 @@ func testSeasonsGraph(t *testing.T) {
 
 			"north horon default winter": "",
 			"north horon default summer": "start",
-		}, "village portal", false, false)
+		}, "village portal", nil, false)
 	checkReach(t, g,
 		map[string]string{
 			"sword 1":            "d0 sword chest",
@@ -77,12 +83,12 @@ func testSeasonsGraph(t *testing.T) {
 
 			"north horon default winter": "",
 			"north horon default summer": "start",
-		}, "village portal", true, true)
+		}, "village portal", graph.Tricks{"tight jumps": true}, true)
 
 	// make sure that all slots in the game are reachable, given vanilla
 	// progression.
-	for slotName, _ := range rom.ItemSlots {
-		r := NewRoute(rom.GameSeasons)
+	for slotName, _ := range ctx.ItemSlots {
+		r := NewRoute(ctx)
 		g := r.Graph
 		checkReach(t, g, map[string]string{
 			"sword 1":            "d0 sword chest",
@@ -124,14 +130,14 @@ func testSeasonsGraph(t *testing.T) {
 			"red ore":       "subrosia village chest",
 			"blue ore":      "subrosian wilds chest",
 			"hard ore":      "great furnace",
-		}, slotName, false, true)
+		}, slotName, nil, true)
 	}
 }
 
 // check that graph logic is working as expected
 func testAgesGraph(t *testing.T) {
-	rom.Init(rom.GameAges)
-	r := NewRoute(rom.GameAges)
+	ctx := rom.NewContext(rom.GameAges)
+	r := NewRoute(ctx)
 	g := r.Graph
 
 	checkReach(t, g, map[string]string{
@@ -140,17 +146,17 @@ func testAgesGraph(t *testing.T) {
 		"satchel 1":        "maku tree",
 		"ember tree seeds": "south lynna tree",
 		"graveyard key":    "grave under tree",
-	}, "enter d1", false, true)
+	}, "enter d1", nil, true)
 
 	checkReach(t, g, map[string]string{
 		"harp 1":     "starting chest",
 		"harp 2":     "nayru's house",
 		"bracelet 1": "black tower worker",
-	}, "enter d2", false, true)
+	}, "enter d2", nil, true)
 
 	checkReach(t, g, map[string]string{
 		"dimitri's flute": "starting chest",
-	}, "enter d3", false, true)
+	}, "enter d3", nil, true)
 
 	checkReach(t, g, map[string]string{
 		"harp 1":     "starting chest",
@@ -159,7 +165,7 @@ func testAgesGraph(t *testing.T) {
 		"flippers 1": "lynna city chest",
 		"sword 1":    "fairies' woods chest",
 		"tuni nut":   "tokkey's composition",
-	}, "symmetry past", false, true)
+	}, "symmetry past", nil, true)
 
 	checkReach(t, g, map[string]string{
 		"sword 1":            "starting chest",
@@ -175,7 +181,7 @@ func testAgesGraph(t *testing.T) {
 		"harp 2":             "d1 crossroads",
 		"pegasus tree seeds": "rolling ridge west tree",
 		"crown key":          "under moblin keep",
-	}, "enter d5", false, true)
+	}, "enter d5", nil, true)
 
 	checkReach(t, g, map[string]string{
 		"harp 1":      "starting chest",
@@ -184,7 +190,7 @@ func testAgesGraph(t *testing.T) {
 		"flippers 2":  "fairies' woods chest",
 		"feather":     "lynna city chest",
 		"mermaid key": "hidden tokay cave",
-	}, "enter d6 past", false, true)
+	}, "enter d6 past", nil, true)
 
 	checkReach(t, g, map[string]string{
 		"harp 1":          "starting chest",
@@ -193,7 +199,7 @@ func testAgesGraph(t *testing.T) {
 		"flippers 2":      "fairies' woods chest",
 		"feather":         "lynna city chest",
 		"old mermaid key": "hidden tokay cave",
-	}, "enter d6 present", false, true)
+	}, "enter d6 present", nil, true)
 
 	checkReach(t, g, map[string]string{
 		"harp 1":           "starting chest",
@@ -207,7 +213,7 @@ func testAgesGraph(t *testing.T) {
 		"ember tree seeds": "zora village tree",
 		"fairy powder":     "grave under tree",
 		"graveyard key":    "under crescent island",
-	}, "enter d7", false, true)
+	}, "enter d7", nil, true)
 
 	checkReach(t, g, map[string]string{
 		"sword 1":       "starting chest",
@@ -218,12 +224,12 @@ func testAgesGraph(t *testing.T) {
 		"bombs, 10":     "tokay crystal cave",
 		"bracelet 1":    "ambi's palace chest",
 		"cane":          "tokay bomb cave",
-	}, "enter d8", false, true)
+	}, "enter d8", nil, true)
 
 	// make sure that all slots in the game are reachable, given vanilla
 	// progression.
-	for slotName, _ := range rom.ItemSlots {
-		r := NewRoute(rom.GameAges)
+	for slotName, _ := range ctx.ItemSlots {
+		r := NewRoute(ctx)
 		g := r.Graph
 		checkReach(t, g, map[string]string{
 			"sword 1":            "starting chest",
@@ -269,25 +275,98 @@ func testAgesGraph(t *testing.T) {
 			"zora scale":         "zora's reward",
 			"tokay eyeball":      "piratian captain",
 			"bracelet 2":         "d8 floor puzzle",
-		}, slotName, false, true)
+		}, slotName, nil, true)
+	}
+}
+
+// check that the hero's cave key stays in the hero's cave when it's shuffled
+// within its dungeon, and that the route is still completable.
+func TestD0Key(t *testing.T) {
+	ctx := rom.NewContext(rom.GameSeasons)
+	ctx.SetD0KeyShuffle(true)
+
+	modes := dungeonItemModes{
+		SmallKeys: placeOwnDungeon,
+		BossKeys:  placeOwnDungeon,
+		Maps:      placeOwnDungeon,
+		Compasses: placeOwnDungeon,
+	}
+	ri := findRoute(ctx, 0, routeOptions{Modes: modes}, false,
+		func(string, ...interface{}) {})
+	if ri == nil {
+		t.Fatal("no route found")
+	}
+
+	placed := false
+	for slot, item := range getChecks(ri) {
+		if item.Name == "d0 small key" {
+			placed = true
+			if dungeonIndex(slot) != 0 {
+				t.Errorf("%s placed in %s", item.Name, slot.Name)
+			}
+		}
+	}
+	if !placed {
+		t.Error("d0 small key not placed")
+	}
+
+	// the key node has to come from the placed key, not the chest.
+	parents := ri.Route.Graph["d0 key A"].Parents()
+	if len(parents) != 1 || parents[0].Name != "d0 small key" {
+		t.Error("d0 key A doesn't depend on the shuffled key")
+	}
+}
+
+// check that boss keys can be placed outside their dungeons, that removed
+// maps aren't placed, and that vanilla compasses stay in their original slots.
+func TestDungeonItemModes(t *testing.T) {
+	ctx := rom.NewContext(rom.GameSeasons)
+	ctx.SetBossKeysAnywhere(true)
+
+	modes := dungeonItemModes{
+		SmallKeys: placeVanilla,
+		BossKeys:  placeAnywhere,
+		Maps:      placeRemoved,
+		Compasses: placeVanilla,
+	}
+	ri := findRoute(ctx, 0, routeOptions{Modes: modes}, false,
+		func(string, ...interface{}) {})
+	if ri == nil {
+		t.Fatal("no route found")
+	}
+
+	bossKeys := 0
+	for slot, item := range getChecks(ri) {
+		vanilla := ctx.FindTreasureName(ctx.ItemSlots[slot.Name].Treasure)
+		switch {
+		case strings.HasSuffix(item.Name, "boss key"):
+			bossKeys++
+		case strings.HasSuffix(item.Name, "dungeon map"):
+			t.Errorf("%s placed in %s", item.Name, slot.Name)
+		case item.Name == "compass" && vanilla != "compass":
+			t.Errorf("%s placed in %s", item.Name, slot.Name)
+		}
+	}
+	if bossKeys != 8 {
+		t.Errorf("want 8 boss keys, got %d", bossKeys)
 	}
 }
 
 func BenchmarkGraphExplore(b *testing.B) {
 	// init graph
-	r := NewRoute(rom.GameSeasons)
+	r := NewRoute(rom.NewContext(rom.GameSeasons))
 	b.ResetTimer()
 
 	// explore all items from the d0 sword chest
 	for name := range logic.SeasonsExtraItems() {
-		r.Graph.Explore(make(map[*graph.Node]bool), false, r.Graph[name])
+		r.Graph.Explore(make(map[*graph.Node]bool), nil, r.Graph[name])
 	}
 }
 
 // helper function for testing whether a node is reachable given a certain
 // slotting
 func checkReach(t *testing.T, g graph.Graph, parents map[string]string,
-	target string, hard, expect bool) {
+	target string, tricks graph.Tricks, expect bool) {
 	t.Helper()
 
 	// add parents at the start of the function, and remove them at the end. if
@@ -309,9 +388,9 @@ func checkReach(t *testing.T, g graph.Graph, parents map[string]string,
 			}
 		}
 	}()
-	g.ExploreFromStart(hard)
+	g.ExploreFromStart(tricks)
 
-	if (g[target].GetMark(g[target], hard) == graph.MarkTrue) != expect {
+	if (g[target].GetMark(g[target], tricks) == graph.MarkTrue) != expect {
 		if expect {
 			t.Errorf("expected to reach %s, but could not", target)
 		} else {
@@ -319,3 +398,396 @@ func checkReach(t *testing.T, g graph.Graph, parents map[string]string,
 		}
 	}
 }
+
+// check that planned placements are kept, and that everything else is still
+// randomized into a completable route.
+func TestPlan(t *testing.T) {
+	ctx := rom.NewContext(rom.GameSeasons)
+
+	p, err := parseYAMLPlan([]byte(`# test plan
+items:
+  maku tree: feather 1
+  "member's shop 1": 'bracelet' # comment
+trees:
+  horon village seed tree: ember tree seeds
+  north horon seed tree: ember tree seeds
+seasons:
+  spool swamp: winter
+companion: moosh
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.check(rom.GameSeasons); err != nil {
+		t.Fatal(err)
+	}
+
+	ri := findRoute(ctx, 0, routeOptions{Plan: p}, false,
+		func(string, ...interface{}) {})
+	if ri == nil {
+		t.Fatal("no route found")
+	}
+
+	for slotName, itemName := range p.placements() {
+		parents := ri.Route.Graph[itemName].Parents()
+		found := false
+		for _, parent := range parents {
+			if parent.Name == slotName {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s not placed in %s", itemName, slotName)
+		}
+	}
+	if ri.Companion != moosh {
+		t.Errorf("want companion %d, got %d", moosh, ri.Companion)
+	}
+	if season := seasonsByID[ri.Seasons["spool swamp"]]; season != "winter" {
+		t.Errorf("want winter in spool swamp, got %s", season)
+	}
+}
+
+// check that plans that can't work are rejected.
+func TestBadPlan(t *testing.T) {
+	ctx := rom.NewContext(rom.GameSeasons)
+
+	for _, p := range []*plan{
+		{Items: map[string]string{"member's shop 1": "member's card"}},
+		{Items: map[string]string{"maku tree": "ember tree seeds"}},
+		{Items: map[string]string{"not a slot": "feather 1"}},
+	} {
+		// a plan that can't work shouldn't be retried with other seeds
+		tries := 0
+		if findRoute(ctx, 0, routeOptions{Plan: p}, false,
+			func(s string, a ...interface{}) {
+				if strings.HasPrefix(s, "trying seed") {
+					tries++
+				}
+			}) != nil {
+			t.Errorf("found route for bad plan: %v", p.Items)
+		}
+		if tries > 1 {
+			t.Errorf("bad plan tried %d times: %v", tries, p.Items)
+		}
+	}
+
+	if _, err := parseJSONPlan([]byte(`{"item": {}}`)); err == nil {
+		t.Error("no error for unknown plan field")
+	}
+	if err := (&plan{Companion: "ricky"}).check(rom.GameSeasons); err != nil {
+		t.Error(err)
+	}
+	if err := (&plan{Companion: "epona"}).check(rom.GameSeasons); err == nil {
+		t.Error("no error for unknown companion")
+	}
+}
+
+// check that starting items are taken out of the item pool.
+func TestStartingItems(t *testing.T) {
+	ctx := rom.NewContext(rom.GameSeasons)
+
+	ro := routeOptions{Start: []string{"feather 1", "rupees, 100"}}
+	ri := findRoute(ctx, 0, ro, false,
+		func(string, ...interface{}) {})
+	if ri == nil {
+		t.Fatal("no route found")
+	}
+
+	parents := ri.Route.Graph["feather 1"].Parents()
+	if len(parents) != 1 || parents[0].Name != "start" {
+		t.Errorf("feather 1 has wrong parents: %v", parents)
+	}
+	if ri.Route.Rupees < 100 {
+		t.Errorf("starting rupees not counted: %d", ri.Route.Rupees)
+	}
+}
+
+// check that every slot belongs to a hint region, and that hints are
+// generated.
+func TestHints(t *testing.T) {
+	for _, game := range []int{rom.GameSeasons, rom.GameAges} {
+		ctx := rom.NewContext(game)
+
+		ri := findRoute(ctx, 0, routeOptions{}, false,
+			func(string, ...interface{}) {})
+		if ri == nil {
+			t.Fatal("no route found")
+		}
+
+		regions := getSlotRegions(game, getChecks(ri))
+		for name := range ri.Route.Slots {
+			if regions[name] == "" {
+				t.Errorf("%s has no hint region", name)
+			}
+		}
+
+		hints := generateHints(ri, game, nil)
+		if len(hints) == 0 {
+			t.Error("no hints generated")
+		}
+	}
+}
+
+func TestRings(t *testing.T) {
+	ctx := rom.NewContext(rom.GameAges)
+
+	// planned rings have to be among the shuffled ones
+	ro := routeOptions{Rings: true, Plan: &plan{
+		Items: map[string]string{"lynna city chest": "toss ring"},
+	}}
+	ri := findRoute(ctx, 0, ro, false,
+		func(string, ...interface{}) {})
+	if ri == nil {
+		t.Fatal("no route found")
+	}
+	if _, ok := ri.Rings["toss ring"]; !ok {
+		t.Error("planned ring wasn't shuffled in")
+	}
+
+	// every vanilla ring should be replaced exactly once
+	used := make(map[string]bool)
+	for ring, vanilla := range ri.Rings {
+		if used[vanilla] {
+			t.Errorf("%s replaced more than once", vanilla)
+		}
+		used[vanilla] = true
+		if !ctx.IsRing(ring) {
+			t.Errorf("%s isn't a ring", ring)
+		}
+	}
+	for name, slot := range ctx.ItemSlots {
+		vanilla := ctx.FindTreasureName(slot.Treasure)
+		if ctx.IsRing(vanilla) && !used[vanilla] {
+			t.Errorf("ring in %s wasn't replaced", name)
+		}
+	}
+}
+
+func TestJSONSpoiler(t *testing.T) {
+	ctx := rom.NewContext(rom.GameSeasons)
+
+	ro := routeOptions{}
+	ri := findRoute(ctx, 0, ro, false,
+		func(string, ...interface{}) {})
+	if ri == nil {
+		t.Fatal("no route found")
+	}
+	checks := getChecks(ri)
+	spheres := getSpheres(ri.Route.Graph, checks, ro.Tricks)
+
+	b, err := json.Marshal(getJSONSpoiler(rom.GameSeasons, ri, ro,
+		[]byte{0xab}, checks, spheres))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var js jsonSpoiler
+	if err := json.Unmarshal(b, &js); err != nil {
+		t.Fatal(err)
+	}
+
+	if js.Seed != "00000000" || js.Checksum != "ab" ||
+		js.Settings.Game != "seasons" {
+		t.Errorf("wrong header: %s, %s, %s",
+			js.Seed, js.Checksum, js.Settings.Game)
+	}
+	if len(js.Checks) != len(checks) {
+		t.Errorf("%d checks in spoiler, want %d", len(js.Checks), len(checks))
+	}
+	for _, check := range js.Checks {
+		if check.Sphere < -1 || check.Sphere >= len(spheres) {
+			t.Errorf("bad sphere for %s: %d", check.Slot, check.Sphere)
+		}
+	}
+	if len(js.SeedTrees) != 6 {
+		t.Errorf("%d seed trees in spoiler, want 6", len(js.SeedTrees))
+	}
+	if len(js.Seasons) == 0 {
+		t.Error("no seasons in spoiler")
+	}
+}
+
+// check that settings strings restore the options they were made from.
+func TestSettingsString(t *testing.T) {
+	ctx := rom.NewContext(rom.GameSeasons)
+	defer func() {
+		for _, o := range options {
+			o.set(o.def)
+		}
+		flagSeed, flagTricks, flagStart, inlinePlan = "", "", "", nil
+	}()
+
+	for name, value := range map[string]string{
+		"d0key":     "anywhere",
+		"bosskeys":  "vanilla",
+		"maps":      "dungeon",
+		"compasses": "removed",
+		"rings":     "true",
+		"nomusic":   "true",
+	} {
+		for _, o := range options {
+			if o.name == name {
+				o.set(value)
+			}
+		}
+	}
+	flagTricks = "bomb jumps," + logic.ShovelManip
+	flagStart = "feather 1,rupees, 100"
+	inlinePlan = &plan{Companion: "moosh"}
+	ro, err := parseRouteFlags(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := getSettingsString(rom.GameSeasons, 0x1234abcd)
+
+	for _, o := range options {
+		o.set(o.def)
+	}
+	inlinePlan = nil
+	if err := applySettingsString(s, rom.GameSeasons); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parseRouteFlags(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flagSeed != "1234abcd" {
+		t.Errorf("want seed 1234abcd, got %s", flagSeed)
+	}
+	if !flagNoMusic {
+		t.Error("music option not restored")
+	}
+	if !reflect.DeepEqual(got, ro) {
+		t.Errorf("want options %+v, got %+v", ro, got)
+	}
+	if s2 := getSettingsString(rom.GameSeasons, 0x1234abcd); s2 != s {
+		t.Errorf("settings string changed from %s to %s", s, s2)
+	}
+
+	if err := applySettingsString(s, rom.GameAges); err == nil {
+		t.Error("no error for settings string from other game")
+	}
+	if err := applySettingsString("0.0.0"+s[len(version):],
+		rom.GameSeasons); err == nil {
+		t.Error("no error for settings string from other version")
+	}
+
+	if err := checkSettingsFlags(map[string]bool{"seed": true}); err == nil {
+		t.Error("no error for -seed with -settings")
+	}
+	if err := checkSettingsFlags(map[string]bool{"nomusic": true}); err == nil {
+		t.Error("no error for saved option with -settings")
+	}
+	if err := checkSettingsFlags(map[string]bool{"spoiler": true}); err != nil {
+		t.Error(err)
+	}
+
+	flagStart = strings.Repeat("x", maxSettingsLength+1)
+	if err := checkOptions(); err == nil {
+		t.Error("no error for option too long for settings string")
+	}
+}
+
+// check that option values are validated for each kind of option.
+func TestOptions(t *testing.T) {
+	var b bool
+	var s string
+	var n int
+	for _, test := range []struct {
+		o     *option
+		value string
+		ok    bool
+	}{
+		{&option{kind: optionBool, boolVar: &b}, "true", true},
+		{&option{kind: optionBool, boolVar: &b}, "yes", false},
+		{&option{kind: optionEnum, strVar: &s,
+			values: []string{"a", "b"}}, "b", true},
+		{&option{kind: optionEnum, strVar: &s,
+			values: []string{"a", "b"}}, "c", false},
+		{&option{kind: optionInt, intVar: &n, min: 1, max: 3}, "3", true},
+		{&option{kind: optionInt, intVar: &n, min: 1, max: 3}, "4", false},
+		{&option{kind: optionList, strVar: &s,
+			values: []string{"a", "b"}}, "b,a", true},
+		{&option{kind: optionList, strVar: &s,
+			values: []string{"a", "b"}}, "a,c", false},
+	} {
+		err := test.o.set(test.value)
+		if test.ok && (err != nil || test.o.get() != test.value) {
+			t.Errorf("couldn't set value %q: %v", test.value, err)
+		} else if !test.ok && err == nil {
+			t.Errorf("no error for value %q", test.value)
+		}
+	}
+}
+
+// check that presets set options, and that written presets load the same
+// options.
+func TestPreset(t *testing.T) {
+	ctx := rom.NewContext(rom.GameSeasons)
+	defer func() {
+		for _, o := range options {
+			o.set(o.def)
+		}
+		flagTricks, flagStart, flagPlan, inlinePlan = "", "", "", nil
+	}()
+
+	dir, err := ioutil.TempDir("", "preset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tomlFile := filepath.Join(dir, "test.toml")
+	if err := ioutil.WriteFile(tomlFile, []byte(`# test preset
+d0key = "anywhere"
+rings = true # comment
+tricks = ["bomb jumps", 'poe skip']
+start = ["feather 1", "rupees, 100"]
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyPreset(tomlFile); err != nil {
+		t.Fatal(err)
+	}
+	ro, err := parseRouteFlags(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ro.Rings || ro.Modes.SmallKeys != placeAnywhere ||
+		len(ro.Tricks) != 2 || len(ro.Start) != 2 {
+		t.Fatalf("preset options not applied: %+v", ro)
+	}
+
+	inlinePlan = &plan{Companion: "moosh"}
+	if ro, err = parseRouteFlags(ctx); err != nil {
+		t.Fatal(err)
+	}
+	jsonFile := filepath.Join(dir, "test.json")
+	if err := writePreset(jsonFile, ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range options {
+		o.set(o.def)
+	}
+	inlinePlan = nil
+	if err := applyPreset(jsonFile); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parseRouteFlags(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, ro) {
+		t.Errorf("want options %+v, got %+v", ro, got)
+	}
+
+	for _, s := range []string{"rings = yes", "tricks = [bomb jumps]",
+		"songs = 3", "maps = \"nowhere\"", "preset = \"other.toml\""} {
+		if err := ioutil.WriteFile(tomlFile, []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := applyPreset(tomlFile); err == nil {
+			t.Errorf("no error for preset %q", s)
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/jangler/oracles-randomizer/graph"
 	"github.com/jangler/oracles-randomizer/logic"
-	"github.com/jangler/oracles-randomizer/rom"
 )
 
 // returns true iff the node is in the list.
@@ -21,8 +20,9 @@ func nodeInList(n *graph.Node, l *list.List) bool {
 }
 
 func trySlotRandomItem(r *Route, src *rand.Rand, itemPool,
-	slotPool *list.List, countFunc func(*Route, bool) int, numUsedSlots int,
-	hard, fillUnused bool) (usedItem, usedSlot *list.Element) {
+	slotPool *list.List, countFunc func(*Route, graph.Tricks) int,
+	numUsedSlots int, tricks graph.Tricks,
+	fillUnused bool) (usedItem, usedSlot *list.Element) {
 	// we're dead
 	if slotPool.Len() == 0 || itemPool.Len() == 0 {
 		return nil, nil
@@ -31,7 +31,7 @@ func trySlotRandomItem(r *Route, src *rand.Rand, itemPool,
 	// this is the last slot, so it has to open up progression
 	var initialCount int
 	if slotPool.Len() == numUsedSlots+1 && !fillUnused {
-		initialCount = countFunc(r, hard)
+		initialCount = countFunc(r, tricks)
 	}
 
 	// try placing an item in the first slot until one fits
@@ -39,22 +39,22 @@ func trySlotRandomItem(r *Route, src *rand.Rand, itemPool,
 		slot := es.Value.(*graph.Node)
 
 		r.Graph.ClearMarks()
-		if slot.GetMark(slot, hard) != graph.MarkTrue ||
-			!canAffordSlot(r, slot, hard) {
+		if slot.GetMark(slot, tricks) != graph.MarkTrue ||
+			!canAffordSlot(r, slot, tricks) {
 			continue
 		}
 
 		for ei := itemPool.Front(); ei != nil; ei = ei.Next() {
 			item := ei.Value.(*graph.Node)
 
-			if !itemFitsInSlot(item, slot, src) {
+			if !itemFitsInSlot(r, item, slot, src) {
 				continue
 			}
 
 			item.AddParents(slot)
 
 			if slotPool.Len() == numUsedSlots+1 && !fillUnused {
-				newCount := countFunc(r, hard)
+				newCount := countFunc(r, tricks)
 				if newCount <= initialCount {
 					item.RemoveParent(slot)
 					continue
@@ -84,7 +84,8 @@ func getSortedKeys(g graph.Graph, src *rand.Rand) []string {
 // checks whether the item fits in the slot due to things like seeds only going
 // in trees, certain item slots not accomodating sub IDs. this doesn't check
 // for softlocks or the availability of the slot and item.
-func itemFitsInSlot(itemNode, slotNode *graph.Node, src *rand.Rand) bool {
+func itemFitsInSlot(r *Route, itemNode, slotNode *graph.Node,
+	src *rand.Rand) bool {
 	// dummy shop slots 1 and 2 can only hold their vanilla items.
 	if slotNode.Name == "shop, 20 rupees" && itemNode.Name != "bombs, 10" {
 		return false
@@ -117,9 +118,15 @@ func itemFitsInSlot(itemNode, slotNode *graph.Node, src *rand.Rand) bool {
 		}
 	}
 
+	// small keys stay in their own dungeon unless they can go anywhere
+	if r.Modes.SmallKeys == placeOwnDungeon && r.ROM.IsSmallKey(itemNode.Name) &&
+		dungeonIndex(itemNode) != dungeonIndex(slotNode) {
+		return false
+	}
+
 	// rod of seasons has special graphics something
 	if slotNode.Name == "temple of seasons" &&
-		!rom.CanSlotAsRod(itemNode.Name) {
+		!r.ROM.CanSlotAsRod(itemNode.Name) {
 		return false
 	}
 
@@ -140,8 +147,6 @@ func itemFitsInSlot(itemNode, slotNode *graph.Node, src *rand.Rand) bool {
 	default:
 		return !slotIsSeedTree(slotNode.Name)
 	}
-
-	return true
 }
 
 func slotIsSeedTree(name string) bool {
@@ -157,17 +162,17 @@ func slotIsSeedTree(name string) bool {
 	return false
 }
 
-func canAffordSlot(r *Route, slot *graph.Node, hard bool) bool {
+func canAffordSlot(r *Route, slot *graph.Node, tricks graph.Tricks) bool {
 	// if it doesn't cost anything, of course it's affordable
 	balance := logic.NodeValues[slot.Name]
 	if balance >= 0 {
 		return true
 	}
 
-	// in hard mode, 100 rupee manips with shovel are in logic
-	if hard {
+	// 100 rupee manips with shovel can be in logic
+	if tricks[logic.ShovelManip] {
 		shovel := r.Graph["shovel"]
-		if shovel.GetMark(shovel, hard) == graph.MarkTrue {
+		if shovel.GetMark(shovel, tricks) == graph.MarkTrue {
 			return true
 		}
 	}
@@ -177,7 +182,7 @@ func canAffordSlot(r *Route, slot *graph.Node, hard bool) bool {
 	for _, node := range r.Graph {
 		value := logic.NodeValues[node.Name]
 		if value != 0 && node != slot &&
-			node.GetMark(node, hard) == graph.MarkTrue {
+			node.GetMark(node, tricks) == graph.MarkTrue {
 			balance += value
 		}
 	}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jangler/oracles-randomizer/rom"
+)
+
+// the longest list, string, or file option value that fits in a settings
+// string.
+const maxSettingsLength = 0xffff
+
+// getSettingsString returns a string that encodes the game, seed, and every
+// option that affects the output ROM. The data is compressed, since plans
+// can make it long. The string can only be decoded by the same version of the
+// randomizer.
+func getSettingsString(game int, seed uint32) string {
+	var b bytes.Buffer
+	b.WriteByte(byte(game))
+	binary.Write(&b, binary.BigEndian, seed)
+	writeOptions(&b)
+
+	var z bytes.Buffer
+	w, _ := flate.NewWriter(&z, flate.BestCompression)
+	w.Write(b.Bytes())
+	w.Close()
+	return version + ":" + base64.RawURLEncoding.EncodeToString(z.Bytes())
+}
+
+// applySettingsString sets the CLI option values to the ones encoded in a
+// settings string. It returns an error if the string is invalid, or if it's
+// for a different game or version.
+func applySettingsString(s string, game int) error {
+	i := strings.LastIndex(s, ":")
+	if i == -1 {
+		return fmt.Errorf("invalid settings string")
+	}
+	if s[:i] != version {
+		return fmt.Errorf("settings string is for version %s, not %s",
+			s[:i], version)
+	}
+	z, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(s[i+1:]))
+	if err != nil {
+		return fmt.Errorf("invalid settings string")
+	}
+	data, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(z)))
+	if err != nil || len(data) < 5 {
+		return fmt.Errorf("invalid settings string")
+	}
+	r := bytes.NewReader(data)
+
+	if stringGame, _ := r.ReadByte(); int(stringGame) != game {
+		return fmt.Errorf("settings string is for a different game")
+	}
+	var seed uint32
+	binary.Read(r, binary.BigEndian, &seed)
+	flagSeed = fmt.Sprintf("%08x", seed)
+
+	return readOptions(r)
+}
+
+// checkSettingsFlags returns an error if any of the given flags set something
+// that a settings string also sets, since the settings string would override
+// them.
+func checkSettingsFlags(given map[string]bool) error {
+	var names []string
+	for _, name := range []string{"seed", "preset"} {
+		if given[name] {
+			names = append(names, "-"+name)
+		}
+	}
+	for _, o := range options {
+		if given[o.name] && !o.unsaved {
+			names = append(names, "-"+o.name)
+		}
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("-settings can't be used with %s",
+			strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// checkSettingsLengths returns an error if any saved option's value is too
+// long to fit in a settings string.
+func checkSettingsLengths() error {
+	for _, o := range options {
+		if o.unsaved {
+			continue
+		}
+
+		var n int
+		switch o.kind {
+		case optionList, optionString:
+			n = len(*o.strVar)
+		case optionFile:
+			contents, err := o.contents()
+			if err != nil {
+				return err
+			}
+			n = len(contents)
+		default:
+			continue
+		}
+		if n > maxSettingsLength {
+			return fmt.Errorf("-%s is too long for a settings string "+
+				"(%d bytes; the limit is %d)", o.name, n, maxSettingsLength)
+		}
+	}
+	return nil
+}
+
+// writes the values of the saved options. bools are packed into a bit field,
+// enums are value indexes, ints are 32 bits, and other options are strings
+// with 16-bit lengths. file options are written as their contents. values
+// have to have been checked by checkSettingsLengths first.
+func writeOptions(b *bytes.Buffer) {
+	var bools []bool
+	for _, o := range options {
+		if !o.unsaved && o.kind == optionBool {
+			bools = append(bools, *o.boolVar)
+		}
+	}
+	b.Write(packBits(bools))
+
+	for _, o := range options {
+		if o.unsaved {
+			continue
+		}
+		switch o.kind {
+		case optionEnum:
+			b.WriteByte(byte(o.index(*o.strVar)))
+		case optionInt:
+			binary.Write(b, binary.BigEndian, int32(*o.intVar))
+		case optionList, optionString:
+			writeString(b, []byte(*o.strVar))
+		case optionFile:
+			// errors reading the file are caught before the seed is made
+			contents, _ := o.contents()
+			writeString(b, contents)
+		}
+	}
+}
+
+// reads the values written by writeOptions and sets the options to them.
+func readOptions(r *bytes.Reader) error {
+	n := 0
+	for _, o := range options {
+		if !o.unsaved && o.kind == optionBool {
+			n++
+		}
+	}
+	bits := make([]byte, (n+7)/8)
+	if _, err := io.ReadFull(r, bits); err != nil {
+		return fmt.Errorf("invalid settings string")
+	}
+
+	i := 0
+	for _, o := range options {
+		if o.unsaved {
+			continue
+		}
+
+		var err error
+		switch o.kind {
+		case optionBool:
+			*o.boolVar = bits[i/8]&(1<<uint(i%8)) != 0
+			i++
+		case optionEnum:
+			var index byte
+			if index, err = r.ReadByte(); err == nil {
+				if int(index) >= len(o.values) {
+					return fmt.Errorf("invalid settings string")
+				}
+				*o.strVar = o.values[index]
+			}
+		case optionInt:
+			var v int32
+			if err = binary.Read(r, binary.BigEndian, &v); err == nil {
+				err = o.set(fmt.Sprint(v))
+			}
+		case optionList, optionString:
+			var v []byte
+			if v, err = readString(r); err == nil {
+				err = o.set(string(v))
+			}
+		case optionFile:
+			var v []byte
+			if v, err = readString(r); err == nil {
+				err = o.setContents(v)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("invalid settings string")
+		}
+	}
+
+	return nil
+}
+
+// writes a string with its 16-bit length first.
+func writeString(b *bytes.Buffer, s []byte) {
+	binary.Write(b, binary.BigEndian, uint16(len(s)))
+	b.Write(s)
+}
+
+// reads a string written by writeString.
+func readString(r *bytes.Reader) ([]byte, error) {
+	var n uint16
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, err
+	}
+	s := make([]byte, n)
+	_, err := io.ReadFull(r, s)
+	return s, err
+}
+
+// packs bools into a bit field, least significant bit first.
+func packBits(bools []bool) []byte {
+	bits := make([]byte, (len(bools)+7)/8)
+	for i, v := range bools {
+		if v {
+			bits[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return bits
+}
+
+// returns the item names that identify the seed, based on the ROM's checksum
+// and the settings string.
+func getSeedHash(ctx *rom.Context, checksum []byte,
+	settings string) []string {
+	hash := sha1.Sum(append(append([]byte{}, checksum...), settings...))
+	return ctx.SeedHash(hash[:])
+}
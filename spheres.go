@@ -26,7 +26,7 @@ func getChecks(ri *RouteInfo) map[*graph.Node]*graph.Node {
 // start with no items; sphere 1 is the nodes that can be reached using the
 // items from sphere 0, and so on. each node only belongs to one sphere.
 func getSpheres(g graph.Graph, checks map[*graph.Node]*graph.Node,
-	hard bool) [][]*graph.Node {
+	tricks graph.Tricks) [][]*graph.Node {
 	reached := make(map[*graph.Node]bool)
 	spheres := make([][]*graph.Node, 0)
 
@@ -45,7 +45,7 @@ func getSpheres(g graph.Graph, checks map[*graph.Node]*graph.Node,
 
 		// get the set of newly reachable nodes
 		for _, node := range g {
-			if !reached[node] && node.GetMark(node, hard) == graph.MarkTrue {
+			if !reached[node] && node.GetMark(node, tricks) == graph.MarkTrue {
 				if logic.NodeValues[node.Name] > 0 {
 					rupees += logic.NodeValues[node.Name]
 				}
@@ -66,8 +66,8 @@ func getSpheres(g graph.Graph, checks map[*graph.Node]*graph.Node,
 				reached[item] = true
 				rupees += logic.RupeeValues[item.Name]
 
-				// shovel is worth infinite rupees in hard difficulty
-				if hard && item.Name == "shovel" {
+				// shovel is worth infinite rupees with manips
+				if tricks[logic.ShovelManip] && item.Name == "shovel" {
 					rupees += 2000
 				}
 			}
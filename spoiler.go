@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/jangler/oracles-randomizer/graph"
+	"github.com/jangler/oracles-randomizer/rom"
+)
+
+// spoiler log formats
+const (
+	spoilerText = "text"
+	spoilerJSON = "json"
+	spoilerBoth = "both"
+)
+
+var (
+	companionNames  = []string{"", "ricky", "dimitri", "moosh"}
+	tunicColorNames = []string{"green", "blue", "red", "gold"}
+)
+
+// the machine-readable equivalent of the text spoiler log. names of slots and
+// items are the internal ones used by the logic and plan files.
+type jsonSpoiler struct {
+	Seed       string            `json:"seed"`
+	Version    string            `json:"version"`
+	Checksum   string            `json:"checksum"`
+	SettingsID string            `json:"settingsString"`
+	SeedHash   []string          `json:"seedHash"`
+	Settings   jsonSettings      `json:"settings"`
+	Checks     []jsonCheck       `json:"checks"`
+	SeedTrees  map[string]string `json:"seedTrees"`
+	Seasons    map[string]string `json:"seasons,omitempty"`
+	Rings      map[string]string `json:"rings,omitempty"`
+	Companion  string            `json:"companion"`
+	TunicColor string            `json:"tunicColor"`
+	Hints      []string          `json:"hints,omitempty"`
+}
+
+type jsonSettings struct {
+	Game      string   `json:"game"`
+	Tricks    []string `json:"tricks"`
+	D0Key     string   `json:"d0Key,omitempty"`
+	BossKeys  string   `json:"bossKeys"`
+	Maps      string   `json:"maps"`
+	Compasses string   `json:"compasses"`
+	Rings     bool     `json:"rings"`
+	Hints     bool     `json:"hints"`
+	Music     bool     `json:"music"`
+	Treewarp  bool     `json:"treewarp"`
+	Plan      string   `json:"plan,omitempty"`
+	Start     []string `json:"start,omitempty"`
+}
+
+// a single slot and the item placed in it. the sphere is -1 if the slot
+// can't be reached.
+type jsonCheck struct {
+	Slot   string `json:"slot"`
+	Item   string `json:"item"`
+	Sphere int    `json:"sphere"`
+}
+
+// writes a JSON spoiler log for the route to the given file.
+func writeJSONSpoiler(filename string, game int, ri *RouteInfo,
+	ro routeOptions, checksum []byte, checks map[*graph.Node]*graph.Node,
+	spheres [][]*graph.Node) error {
+	b, err := json.MarshalIndent(
+		getJSONSpoiler(game, ri, ro, checksum, checks, spheres), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, append(b, '\n'), 0644)
+}
+
+// returns the JSON spoiler log data for a route.
+func getJSONSpoiler(game int, ri *RouteInfo, ro routeOptions,
+	checksum []byte, checks map[*graph.Node]*graph.Node,
+	spheres [][]*graph.Node) *jsonSpoiler {
+	js := &jsonSpoiler{
+		Seed:       fmt.Sprintf("%08x", ri.Seed),
+		Version:    version,
+		Checksum:   fmt.Sprintf("%x", checksum),
+		SettingsID: getSettingsString(game, ri.Seed),
+		SeedHash:   ri.SeedHash,
+		Settings:   getJSONSettings(game, ro),
+		Checks:     make([]jsonCheck, 0, len(checks)),
+		SeedTrees:  make(map[string]string),
+		Rings:      ri.Rings,
+		Companion:  companionNames[ri.Companion],
+		TunicColor: tunicColorNames[ri.TunicColor],
+		Hints:      ri.Hints,
+	}
+
+	sphereIndexes := make(map[*graph.Node]int)
+	for i, sphere := range spheres {
+		for _, node := range sphere {
+			sphereIndexes[node] = i
+		}
+	}
+	for slot, item := range checks {
+		sphere, ok := sphereIndexes[slot]
+		if !ok {
+			sphere = -1
+		}
+		js.Checks = append(js.Checks, jsonCheck{
+			Slot:   slot.Name,
+			Item:   item.Name,
+			Sphere: sphere,
+		})
+		if slotIsSeedTree(slot.Name) {
+			js.SeedTrees[slot.Name] = item.Name
+		}
+	}
+	sort.Slice(js.Checks, func(i, j int) bool {
+		return js.Checks[i].Slot < js.Checks[j].Slot
+	})
+
+	if ri.Seasons != nil {
+		js.Seasons = make(map[string]string, len(ri.Seasons))
+		for area, id := range ri.Seasons {
+			js.Seasons[area] = seasonsByID[int(id)]
+		}
+	}
+
+	return js
+}
+
+// returns the settings that a route was generated and written with.
+func getJSONSettings(game int, ro routeOptions) jsonSettings {
+	settings := jsonSettings{
+		Game:      "ages",
+		Tricks:    make([]string, 0, len(ro.Tricks)),
+		BossKeys:  placeModeNames[ro.Modes.BossKeys],
+		Maps:      placeModeNames[ro.Modes.Maps],
+		Compasses: placeModeNames[ro.Modes.Compasses],
+		Rings:     ro.Rings,
+		Hints:     flagHints,
+		Music:     !flagNoMusic,
+		Treewarp:  flagTreewarp,
+		Start:     ro.Start,
+	}
+
+	for trick := range ro.Tricks {
+		settings.Tricks = append(settings.Tricks, trick)
+	}
+	sort.Strings(settings.Tricks)
+	if game == rom.GameSeasons {
+		settings.Game = "seasons"
+		settings.D0Key = placeModeNames[ro.Modes.SmallKeys]
+	}
+	if ro.Plan != nil {
+		settings.Plan = planName()
+	}
+
+	return settings
+}
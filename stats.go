@@ -5,10 +5,12 @@ import (
 	"math/rand"
 	"os"
 	"runtime"
+
+	"github.com/jangler/oracles-randomizer/rom"
 )
 
-// generate a bunch of seeds.
-func generateSeeds(n, game int, hard bool) []*RouteInfo {
+// generate a bunch of seeds. each thread has its own ROM context.
+func generateSeeds(n, game int, ro routeOptions) []*RouteInfo {
 	threads := runtime.NumCPU()
 	dummyLogf := func(string, ...interface{}) {}
 
@@ -16,9 +18,12 @@ func generateSeeds(n, game int, hard bool) []*RouteInfo {
 	routeChan := make(chan *RouteInfo)
 	for i := 0; i < threads; i++ {
 		go func() {
+			ctx := rom.NewContext(game)
+			setDungeonItemModes(ctx, ro.Modes)
 			for i := 0; i < n/threads; i++ {
 				seed := uint32(rand.Int())
-				routeChan <- findRoute(game, seed, hard, false, dummyLogf)
+				routeChan <- findRoute(ctx, seed, ro, false,
+					dummyLogf)
 			}
 		}()
 	}
@@ -35,15 +40,21 @@ func generateSeeds(n, game int, hard bool) []*RouteInfo {
 
 // generate a bunch of seeds and print information about how often items are
 // required, and what spheres they're normally in.
-func logStats(game, trials int, hard bool, logf logFunc) {
-	routes := generateSeeds(trials, game, hard)
+func logStats(game, trials int, ro routeOptions, logf logFunc) {
+	routes := generateSeeds(trials, game, ro)
 
 	// aggregate data on required items
 	meanSpheres := make(map[string]float64)
+	found := 0
 	for _, ri := range routes {
+		if ri == nil {
+			continue
+		}
+		found++
+
 		// total spheres
 		checks := getChecks(ri)
-		spheres := getSpheres(ri.Route.Graph, checks, hard)
+		spheres := getSpheres(ri.Route.Graph, checks, ro.Tricks)
 		for i, sphere := range spheres {
 			for _, node := range sphere {
 				if !node.IsStep {
@@ -57,8 +68,12 @@ func logStats(game, trials int, hard bool, logf logFunc) {
 		}
 	}
 
+	if found == 0 {
+		logf("no routes found")
+		return
+	}
 	for item, totalSpheres := range meanSpheres {
 		logf("%s - %4.1f", getNiceName(item),
-			float64(totalSpheres)/float64(len(routes)))
+			float64(totalSpheres)/float64(found))
 	}
 }
@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
+
+	"github.com/jangler/oracles-randomizer/graph"
+	"github.com/jangler/oracles-randomizer/rom"
 )
 
 const version = "3.1.0"
@@ -33,3 +37,74 @@ func getSummaryChannel(filename string) (chan string, chan int) {
 
 	return c, done
 }
+
+// writes a plain text spoiler log for the route to the given file.
+func writeTextSpoiler(filename string, game int, ri *RouteInfo,
+	ro routeOptions, checksum []byte, checks map[*graph.Node]*graph.Node,
+	spheres [][]*graph.Node) {
+	summary, summaryDone := getSummaryChannel(filename)
+
+	// write info to summary file
+	summary <- fmt.Sprintf("seed: %08x", ri.Seed)
+	summary <- fmt.Sprintf("sha-1 sum: %x", checksum)
+	summary <- fmt.Sprintf("hash: %s", strings.Join(ri.SeedHash, " / "))
+	summary <- fmt.Sprintf("settings: %s",
+		getSettingsString(game, ri.Seed))
+	summary <- fmt.Sprintf("tricks: %s", trickString(ro.Tricks))
+	if game == rom.GameSeasons {
+		summary <- fmt.Sprintf("d0 key: %s",
+			placeModeNames[ro.Modes.SmallKeys])
+	}
+	summary <- fmt.Sprintf("boss keys: %s", placeModeNames[ro.Modes.BossKeys])
+	summary <- fmt.Sprintf("maps: %s", placeModeNames[ro.Modes.Maps])
+	summary <- fmt.Sprintf("compasses: %s",
+		placeModeNames[ro.Modes.Compasses])
+	if ro.Plan != nil {
+		summary <- fmt.Sprintf("plan: %s", planName())
+	}
+	if len(ro.Start) > 0 {
+		summary <- fmt.Sprintf("starting items: %s",
+			strings.Join(ro.Start, ", "))
+	}
+	summary <- ""
+	summary <- ""
+	summary <- "-- progression items --"
+	summary <- ""
+	isJunk := func(name string) bool { return itemIsJunk(ri.Route.ROM, name) }
+	logSpheres(summary, checks, spheres,
+		func(name string) bool { return !isJunk(name) })
+	summary <- ""
+	summary <- "-- other items --"
+	summary <- ""
+	logSpheres(summary, checks, spheres, isJunk)
+	if game == rom.GameSeasons {
+		summary <- ""
+		summary <- "default seasons:"
+		summary <- ""
+		for name, area := range ri.Route.ROM.Seasons {
+			summary <- fmt.Sprintf("%-15s <- %s",
+				name[:len(name)-7], seasonsByID[int(area.New[0])])
+		}
+		summary <- ""
+		summary <- fmt.Sprintf("natzu region <- %s", []string{
+			"", "natzu prairie", "natzu river", "natzu wasteland",
+		}[ri.Companion])
+	} else {
+		summary <- ""
+		summary <- fmt.Sprintf("animal companion <- %s", []string{
+			"", "ricky", "dimitri", "moosh",
+		}[ri.Companion])
+	}
+
+	if ri.Hints != nil {
+		summary <- ""
+		summary <- "hints:"
+		summary <- ""
+		for _, hint := range ri.Hints {
+			summary <- hint
+		}
+	}
+
+	close(summary)
+	<-summaryDone
+}
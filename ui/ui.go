@@ -43,6 +43,7 @@ type modeType int
 const (
 	modeWorking modeType = iota
 	modePrompt
+	modeTextPrompt
 	modeDone
 )
 
@@ -85,6 +86,8 @@ func Run() {
 				switch evt.Key {
 				case termbox.KeyCtrlC, '\x7f': // 7f == backspace
 					input <- rune(evt.Key)
+				case termbox.KeyEnter:
+					input <- '\r'
 				default:
 					input <- evt.Ch
 				}
@@ -106,10 +109,11 @@ func Run() {
 			lines[len(lines)-1] = ln
 			draw(mode)
 		case ch := <-input:
-			if ch == 'q' || ch == '\x03' || mode == modeDone {
+			if ch == '\x03' || mode == modeDone ||
+				(ch == 'q' && mode != modeTextPrompt) {
 				termbox.Close()
 				loop = false
-			} else if mode == modePrompt {
+			} else if mode == modePrompt || mode == modeTextPrompt {
 				prompt <- ch
 			}
 		case <-resize:
@@ -149,7 +153,7 @@ func draw(mode modeType) {
 	drawLine(w, h-1, bottom)
 
 	// draw cursor if applicable
-	if mode == modePrompt {
+	if mode == modePrompt || mode == modeTextPrompt {
 		termbox.SetCursor(x, len(lines)-scroll)
 	} else {
 		termbox.HideCursor()
@@ -310,6 +314,27 @@ func PromptSeed(s string) string {
 	}
 }
 
+// PromptString waits for the user to input a line of text, then returns the
+// string once enter is pressed.
+func PromptString(s string) string {
+	line := []segment{{text: s + " "}, {text: "", el: ellipsisLeft}}
+
+	write <- line
+	change <- modeTextPrompt
+	for {
+		ch := <-prompt
+		if ch == '\r' {
+			change <- modeWorking
+			return strings.TrimSpace(line[1].text)
+		} else if ch == '\x7f' && len(line[1].text) > 0 {
+			line[1].text = line[1].text[:len(line[1].text)-1]
+		} else if ch > ' ' && ch <= '~' {
+			line[1].text += string(ch)
+		}
+		rewrite <- line
+	}
+}
+
 // Done changes the mode to one where no action is taken, and any input closes
 // the program.
 func Done() {